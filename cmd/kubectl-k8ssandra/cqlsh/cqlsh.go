@@ -0,0 +1,181 @@
+package cqlsh
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/k8ssandra/k8ssandra-client/pkg/cassdcutil"
+	"github.com/k8ssandra/k8ssandra-client/pkg/kubernetes"
+	"github.com/k8ssandra/k8ssandra-client/pkg/util"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+var (
+	cqlshExample = `
+	# launch an interactive cqlsh shell on a ready pod of the datacenter
+	%[1]s cqlsh <datacenter>
+
+	# execute a single statement
+	%[1]s cqlsh <datacenter> -e "SELECT * FROM system.peers"
+
+	# execute the statements of a local file
+	%[1]s cqlsh <datacenter> -f schema.cql
+
+	# pass other options to cqlsh
+	%[1]s cqlsh <datacenter> -- --request-timeout=60
+	`
+
+	errNoDatacenterDefined        = fmt.Errorf("no target datacenter given")
+	errExecuteAndFileUsedTogether = fmt.Errorf("--execute and --file can not be used together")
+)
+
+type options struct {
+	configFlags *genericclioptions.ConfigFlags
+	genericclioptions.IOStreams
+	namespace   string
+	dcName      string
+	selector    cassdcutil.PodSelector
+	execute     string
+	file        string
+	params      []string
+	cassManager *cassdcutil.CassManager
+}
+
+func newOptions(streams genericclioptions.IOStreams) *options {
+	return &options{
+		configFlags: genericclioptions.NewConfigFlags(true),
+		IOStreams:   streams,
+	}
+}
+
+// NewCmd provides a cobra command wrapping options
+func NewCmd(streams genericclioptions.IOStreams) *cobra.Command {
+	o := newOptions(streams)
+
+	cmd := &cobra.Command{
+		Use:          "cqlsh [datacenter] [-- cqlsh arguments]",
+		Short:        "run cqlsh as the superuser in a datacenter",
+		Example:      fmt.Sprintf(cqlshExample, "kubectl k8ssandra"),
+		SilenceUsage: true,
+		RunE: func(c *cobra.Command, args []string) error {
+			if err := o.Complete(c, args); err != nil {
+				return err
+			}
+			if err := o.Run(); err != nil {
+				return err
+			}
+
+			return nil
+		},
+	}
+
+	fl := cmd.Flags()
+	fl.StringVarP(&o.execute, "execute", "e", "", "execute the statement and quit")
+	fl.StringVarP(&o.file, "file", "f", "", "execute the statements of the local file and quit")
+	cassdcutil.AddPodSelectorFlags(fl, &o.selector)
+	o.configFlags.AddFlags(fl)
+	return cmd
+}
+
+// Complete parses the arguments and necessary flags to options
+func (c *options) Complete(cmd *cobra.Command, args []string) error {
+	var err error
+
+	if len(args) < 1 || cmd.ArgsLenAtDash() == 0 {
+		return errNoDatacenterDefined
+	}
+
+	if c.execute != "" && c.file != "" {
+		return errExecuteAndFileUsedTogether
+	}
+
+	if err := c.selector.Validate(); err != nil {
+		return err
+	}
+
+	c.dcName = args[0]
+	c.params = args[1:]
+	if c.execute != "" {
+		c.params = append(c.params, "--execute", c.execute)
+	}
+
+	c.namespace, _, err = c.configFlags.ToRawKubeConfigLoader().Namespace()
+	if err != nil {
+		return err
+	}
+
+	restConfig, err := c.configFlags.ToRESTConfig()
+	if err != nil {
+		return err
+	}
+
+	kubeClient, err := kubernetes.GetClientInNamespace(restConfig, c.namespace)
+	if err != nil {
+		return err
+	}
+
+	c.cassManager = cassdcutil.NewManager(kubeClient)
+
+	return nil
+}
+
+// Run writes the superuser credentials to a temporary cqlshrc in the selected pod and runs cqlsh with it. The
+// cqlshrc is removed once cqlsh exits, also if it is interrupted.
+func (c *options) Run() error {
+	ctx := context.Background()
+
+	dc, err := c.cassManager.CassandraDatacenter(ctx, c.dcName, c.namespace)
+	if err != nil {
+		return err
+	}
+
+	pods, err := c.cassManager.SelectPods(ctx, dc, c.selector)
+	if err != nil {
+		return err
+	}
+	podName := pods[0].Name
+
+	username, password, err := c.cassManager.SuperuserCredentials(ctx, dc)
+	if err != nil {
+		return err
+	}
+
+	rc, err := cassdcutil.CqlshRC(username, password)
+	if err != nil {
+		return err
+	}
+
+	secretExec := &util.SecretFileExec{
+		ConfigFlags: c.configFlags,
+		IOStreams:   genericclioptions.IOStreams{Out: c.Out, ErrOut: c.ErrOut},
+		PodName:     podName,
+		Name:        "cqlshrc",
+	}
+
+	switch {
+	case c.file != "":
+		f, err := os.Open(c.file)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		// cqlsh executes the statements it reads from a standard input which is not a terminal
+		secretExec.In = f
+	case c.execute == "":
+		secretExec.In = c.In
+		secretExec.TTY = isTerminal(c.In)
+	}
+
+	return secretExec.Run(rc, func(rcPath string) []string {
+		return cassdcutil.CqlshCommand(rcPath, c.params)
+	})
+}
+
+func isTerminal(in io.Reader) bool {
+	f, ok := in.(*os.File)
+	return ok && term.IsTerminal(int(f.Fd()))
+}
@@ -0,0 +1,144 @@
+package events
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/k8ssandra/k8ssandra-client/pkg/cassdcutil"
+	"github.com/k8ssandra/k8ssandra-client/pkg/kubernetes"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/duration"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/cli-runtime/pkg/printers"
+)
+
+var (
+	eventsExample = `
+	# show the events of a datacenter, its StatefulSets, pods and volumes
+	%[1]s events <datacenter>
+
+	# keep printing new events as they happen
+	%[1]s events <datacenter> --follow
+	`
+
+	errNoDatacenterDefined = fmt.Errorf("no target datacenter given")
+)
+
+type options struct {
+	configFlags *genericclioptions.ConfigFlags
+	genericclioptions.IOStreams
+	namespace   string
+	dcName      string
+	follow      bool
+	cassManager *cassdcutil.CassManager
+}
+
+func newOptions(streams genericclioptions.IOStreams) *options {
+	return &options{
+		configFlags: genericclioptions.NewConfigFlags(true),
+		IOStreams:   streams,
+	}
+}
+
+// NewCmd provides a cobra command wrapping options
+func NewCmd(streams genericclioptions.IOStreams) *cobra.Command {
+	o := newOptions(streams)
+
+	cmd := &cobra.Command{
+		Use:          "events [datacenter]",
+		Short:        "show the Kubernetes events of a CassandraDatacenter and the objects it owns",
+		Example:      fmt.Sprintf(eventsExample, "kubectl k8ssandra"),
+		SilenceUsage: true,
+		RunE: func(c *cobra.Command, args []string) error {
+			if err := o.Complete(c, args); err != nil {
+				return err
+			}
+			if err := o.Run(); err != nil {
+				return err
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVarP(&o.follow, "follow", "f", false, "keep printing new events until interrupted")
+	o.configFlags.AddFlags(cmd.Flags())
+	return cmd
+}
+
+// Complete parses the arguments and necessary flags to options
+func (c *options) Complete(cmd *cobra.Command, args []string) error {
+	var err error
+
+	if len(args) < 1 {
+		return errNoDatacenterDefined
+	}
+
+	c.dcName = args[0]
+
+	c.namespace, _, err = c.configFlags.ToRawKubeConfigLoader().Namespace()
+	if err != nil {
+		return err
+	}
+
+	restConfig, err := c.configFlags.ToRESTConfig()
+	if err != nil {
+		return err
+	}
+
+	kubeClient, err := kubernetes.GetClientInNamespace(restConfig, c.namespace)
+	if err != nil {
+		return err
+	}
+
+	c.cassManager = cassdcutil.NewManager(kubeClient)
+
+	return nil
+}
+
+// Run prints the existing events and, with --follow, the new ones as they are observed
+func (c *options) Run() error {
+	ctx := context.Background()
+
+	dc, err := c.cassManager.CassandraDatacenter(ctx, c.dcName, c.namespace)
+	if err != nil {
+		return err
+	}
+
+	events, err := c.cassManager.ListEvents(ctx, dc)
+	if err != nil {
+		return err
+	}
+
+	if len(events) == 0 && !c.follow {
+		fmt.Fprintf(c.ErrOut, "No events found for datacenter %s in %s namespace.\n", c.dcName, c.namespace)
+		return nil
+	}
+
+	w := printers.GetNewTabWriter(c.Out)
+	fmt.Fprintln(w, "LAST SEEN\tTYPE\tREASON\tOBJECT\tMESSAGE")
+	for i := range events {
+		printEvent(w, &events[i])
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	if c.follow {
+		c.cassManager.FollowEvents(ctx, dc, events, func(event *corev1.Event) {
+			printEvent(w, event)
+			w.Flush()
+		})
+	}
+
+	return nil
+}
+
+func printEvent(w io.Writer, event *corev1.Event) {
+	fmt.Fprintf(w, "%s\t%s\t%s\t%s/%s\t%s\n", duration.HumanDuration(time.Since(cassdcutil.EventTime(event))), event.Type, event.Reason,
+		strings.ToLower(event.InvolvedObject.Kind), event.InvolvedObject.Name, strings.TrimSpace(event.Message))
+}
@@ -2,13 +2,19 @@ package k8ssandra
 
 import (
 	// "github.com/k8ssandra/k8ssandra-client/cmd/kubectl-k8ssandra/cleaner"
-	// "github.com/k8ssandra/k8ssandra-client/cmd/kubectl-k8ssandra/cqlsh"
 	// "github.com/k8ssandra/k8ssandra-client/cmd/kubectl-k8ssandra/crds"
 	// "github.com/k8ssandra/k8ssandra-client/cmd/kubectl-k8ssandra/edit"
 	// "github.com/k8ssandra/k8ssandra-client/cmd/kubectl-k8ssandra/list"
 	// "github.com/k8ssandra/k8ssandra-client/cmd/kubectl-k8ssandra/migrate"
-	// "github.com/k8ssandra/k8ssandra-client/cmd/kubectl-k8ssandra/nodetool"
+	"github.com/k8ssandra/k8ssandra-client/cmd/kubectl-k8ssandra/cqlsh"
+	"github.com/k8ssandra/k8ssandra-client/cmd/kubectl-k8ssandra/events"
+	"github.com/k8ssandra/k8ssandra-client/cmd/kubectl-k8ssandra/logs"
+	"github.com/k8ssandra/k8ssandra-client/cmd/kubectl-k8ssandra/nodetool"
 	"github.com/k8ssandra/k8ssandra-client/cmd/kubectl-k8ssandra/operate"
+	"github.com/k8ssandra/k8ssandra-client/cmd/kubectl-k8ssandra/portforward"
+	"github.com/k8ssandra/k8ssandra-client/cmd/kubectl-k8ssandra/status"
+	"github.com/k8ssandra/k8ssandra-client/cmd/kubectl-k8ssandra/task"
+	"github.com/k8ssandra/k8ssandra-client/cmd/kubectl-k8ssandra/topology"
 	"github.com/k8ssandra/k8ssandra-client/cmd/kubectl-k8ssandra/users"
 
 	"github.com/spf13/cobra"
@@ -37,14 +43,22 @@ func NewCmd(streams genericclioptions.IOStreams) *cobra.Command {
 	}
 
 	// Add subcommands
-	// cmd.AddCommand(nodetool.NewCmd(streams))
-	// cmd.AddCommand(cqlsh.NewCmd(streams))
+	cmd.AddCommand(nodetool.NewCmd(streams))
+	cmd.AddCommand(cqlsh.NewCmd(streams))
 	// cmd.AddCommand(cleaner.NewCmd(streams))
 	// cmd.AddCommand(crds.NewCmd(streams))
 	// cmd.AddCommand(edit.NewCmd(streams))
 	cmd.AddCommand(operate.NewStartCmd(streams))
 	cmd.AddCommand(operate.NewRestartCmd(streams))
 	cmd.AddCommand(operate.NewStopCmd(streams))
+	cmd.AddCommand(operate.NewScaleCmd(streams))
+	cmd.AddCommand(operate.NewUpgradeCmd(streams))
+	cmd.AddCommand(status.NewCmd(streams))
+	cmd.AddCommand(events.NewCmd(streams))
+	cmd.AddCommand(logs.NewCmd(streams))
+	cmd.AddCommand(portforward.NewCmd(streams))
+	cmd.AddCommand(topology.NewCmd(streams))
+	cmd.AddCommand(task.NewCmd(streams))
 	// cmd.AddCommand(list.NewCmd(streams))
 	// cmd.AddCommand(migrate.NewCmd(streams))
 	cmd.AddCommand(users.NewCmd(streams))
@@ -0,0 +1,192 @@
+package logs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"time"
+
+	cassdcapi "github.com/k8ssandra/cass-operator/apis/cassandra/v1beta1"
+	"github.com/k8ssandra/k8ssandra-client/pkg/cassdcutil"
+	"github.com/k8ssandra/k8ssandra-client/pkg/kubernetes"
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	k8sclient "k8s.io/client-go/kubernetes"
+	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
+)
+
+var (
+	logsExample = `
+	# print the Cassandra logs of every pod in the datacenter, ordered by time
+	%[1]s logs <datacenter>
+
+	# follow the logs of a single rack
+	%[1]s logs <datacenter> --rack <rack> --follow
+
+	# print the errors of the last hour, including the lines of system.log
+	%[1]s logs <datacenter> --since 1h --grep 'ERROR|Exception' --system-logger
+	`
+
+	errNoDatacenterDefined = fmt.Errorf("no target datacenter given")
+)
+
+type options struct {
+	configFlags *genericclioptions.ConfigFlags
+	genericclioptions.IOStreams
+	namespace    string
+	dcName       string
+	rack         string
+	follow       bool
+	since        time.Duration
+	grep         string
+	systemLogger bool
+	timestamps   bool
+	logOptions   cassdcutil.LogOptions
+	pods         corev1client.PodsGetter
+	cassManager  *cassdcutil.CassManager
+}
+
+func newOptions(streams genericclioptions.IOStreams) *options {
+	return &options{
+		configFlags: genericclioptions.NewConfigFlags(true),
+		IOStreams:   streams,
+	}
+}
+
+// NewCmd provides a cobra command wrapping options
+func NewCmd(streams genericclioptions.IOStreams) *cobra.Command {
+	o := newOptions(streams)
+
+	cmd := &cobra.Command{
+		Use:          "logs [datacenter]",
+		Short:        "print the logs of every Cassandra pod in a datacenter",
+		Example:      fmt.Sprintf(logsExample, "kubectl k8ssandra"),
+		SilenceUsage: true,
+		RunE: func(c *cobra.Command, args []string) error {
+			if err := o.Complete(c, args); err != nil {
+				return err
+			}
+			if err := o.Run(); err != nil {
+				return err
+			}
+
+			return nil
+		},
+	}
+
+	fl := cmd.Flags()
+	fl.StringVar(&o.rack, "rack", "", "print only the logs of the pods in this rack")
+	fl.BoolVarP(&o.follow, "follow", "f", false, "keep printing new lines as they are written, in the order they are received")
+	fl.DurationVar(&o.since, "since", 0, "print only the lines newer than a relative duration like 5s, 2m or 3h")
+	fl.StringVar(&o.grep, "grep", "", "print only the lines matching the regular expression")
+	fl.BoolVar(&o.systemLogger, "system-logger", false, fmt.Sprintf("also print the logs of the %s container", cassdcutil.SystemLoggerContainer))
+	fl.BoolVar(&o.timestamps, "timestamps", false, "prefix every line with its timestamp")
+	o.configFlags.AddFlags(fl)
+	return cmd
+}
+
+// Complete parses the arguments and necessary flags to options
+func (c *options) Complete(cmd *cobra.Command, args []string) error {
+	var err error
+
+	if len(args) < 1 {
+		return errNoDatacenterDefined
+	}
+
+	c.dcName = args[0]
+
+	c.logOptions = cassdcutil.LogOptions{
+		Rack:       c.rack,
+		Containers: []string{cassdcutil.CassandraContainer},
+		Follow:     c.follow,
+		Since:      c.since,
+	}
+	if c.systemLogger {
+		c.logOptions.Containers = append(c.logOptions.Containers, cassdcutil.SystemLoggerContainer)
+	}
+	if c.grep != "" {
+		c.logOptions.Grep, err = regexp.Compile(c.grep)
+		if err != nil {
+			return fmt.Errorf("invalid --grep expression: %w", err)
+		}
+	}
+
+	c.namespace, _, err = c.configFlags.ToRawKubeConfigLoader().Namespace()
+	if err != nil {
+		return err
+	}
+
+	restConfig, err := c.configFlags.ToRESTConfig()
+	if err != nil {
+		return err
+	}
+
+	kubeClient, err := kubernetes.GetClientInNamespace(restConfig, c.namespace)
+	if err != nil {
+		return err
+	}
+
+	clientset, err := k8sclient.NewForConfig(restConfig)
+	if err != nil {
+		return err
+	}
+
+	c.pods = clientset.CoreV1()
+	c.cassManager = cassdcutil.NewManager(kubeClient)
+
+	return nil
+}
+
+// Run prints the logs. Without --follow all the lines are read first and printed ordered by their timestamps.
+func (c *options) Run() error {
+	ctx := context.Background()
+
+	dc, err := c.cassManager.CassandraDatacenter(ctx, c.dcName, c.namespace)
+	if err != nil {
+		return err
+	}
+
+	if c.rack != "" && !hasRack(dc.GetRacks(), c.rack) {
+		return fmt.Errorf("rack %s does not exist in datacenter %s", c.rack, c.dcName)
+	}
+
+	var lines []cassdcutil.LogLine
+	err = c.cassManager.StreamLogs(ctx, c.pods, dc, c.logOptions, func(line cassdcutil.LogLine) {
+		if c.follow {
+			c.printLine(line)
+			return
+		}
+		lines = append(lines, line)
+	})
+
+	cassdcutil.SortLogLines(lines)
+	for _, line := range lines {
+		c.printLine(line)
+	}
+
+	// Logs of the remaining pods are still useful when some of the pods are not running
+	var streamErr *cassdcutil.LogStreamError
+	if errors.As(err, &streamErr) {
+		fmt.Fprintf(c.ErrOut, "Warning: %v\n", streamErr)
+		return nil
+	}
+	return err
+}
+
+func (c *options) printLine(line cassdcutil.LogLine) {
+	if c.timestamps && !line.Time.IsZero() {
+		fmt.Fprintf(c.Out, "[%s] %s %s\n", line.Source(), line.Time.Format(time.RFC3339Nano), line.Text)
+		return
+	}
+	fmt.Fprintf(c.Out, "[%s] %s\n", line.Source(), line.Text)
+}
+
+func hasRack(racks []cassdcapi.Rack, name string) bool {
+	for _, rack := range racks {
+		if rack.Name == name {
+			return true
+		}
+	}
+	return false
+}
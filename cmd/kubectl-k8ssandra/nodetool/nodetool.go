@@ -0,0 +1,244 @@
+package nodetool
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	cassdcapi "github.com/k8ssandra/cass-operator/apis/cassandra/v1beta1"
+	"github.com/k8ssandra/k8ssandra-client/pkg/cassdcutil"
+	"github.com/k8ssandra/k8ssandra-client/pkg/kubernetes"
+	"github.com/k8ssandra/k8ssandra-client/pkg/util"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+var (
+	nodetoolExample = `
+	# run nodetool status on a ready pod of the datacenter
+	%[1]s nodetool <datacenter> -- status
+
+	# run nodetool on a given pod
+	%[1]s nodetool <datacenter> --pod <pod> -- info
+
+	# run nodetool on every pod of a rack and print the output of each pod
+	%[1]s nodetool <datacenter> --rack <rack> --all -- tpstats
+	`
+
+	errNoDatacenterDefined   = fmt.Errorf("no target datacenter given")
+	errNoNodetoolArguments   = fmt.Errorf("no nodetool command given, add it after --")
+	errPodAndAllUsedTogether = fmt.Errorf("--pod and --all can not be used together")
+)
+
+type options struct {
+	configFlags *genericclioptions.ConfigFlags
+	genericclioptions.IOStreams
+	namespace   string
+	dcName      string
+	selector    cassdcutil.PodSelector
+	all         bool
+	params      []string
+	cassManager *cassdcutil.CassManager
+}
+
+func newOptions(streams genericclioptions.IOStreams) *options {
+	return &options{
+		configFlags: genericclioptions.NewConfigFlags(true),
+		IOStreams:   streams,
+	}
+}
+
+// NewCmd provides a cobra command wrapping options
+func NewCmd(streams genericclioptions.IOStreams) *cobra.Command {
+	o := newOptions(streams)
+
+	cmd := &cobra.Command{
+		Use:          "nodetool [datacenter] -- [nodetool arguments]",
+		Short:        "run nodetool in the Cassandra container of a datacenter's pods",
+		Example:      fmt.Sprintf(nodetoolExample, "kubectl k8ssandra"),
+		SilenceUsage: true,
+		RunE: func(c *cobra.Command, args []string) error {
+			if err := o.Complete(c, args); err != nil {
+				return err
+			}
+			if err := o.Validate(); err != nil {
+				return err
+			}
+			if err := o.Run(); err != nil {
+				return err
+			}
+
+			return nil
+		},
+	}
+
+	fl := cmd.Flags()
+	cassdcutil.AddPodSelectorFlags(fl, &o.selector)
+	fl.BoolVar(&o.all, "all", false, "run nodetool on every ready pod in parallel")
+	o.configFlags.AddFlags(fl)
+	return cmd
+}
+
+// Complete parses the arguments and necessary flags to options
+func (c *options) Complete(cmd *cobra.Command, args []string) error {
+	var err error
+
+	if len(args) < 1 || cmd.ArgsLenAtDash() == 0 {
+		return errNoDatacenterDefined
+	}
+
+	c.dcName = args[0]
+	c.params = args[1:]
+
+	c.namespace, _, err = c.configFlags.ToRawKubeConfigLoader().Namespace()
+	if err != nil {
+		return err
+	}
+
+	restConfig, err := c.configFlags.ToRESTConfig()
+	if err != nil {
+		return err
+	}
+
+	kubeClient, err := kubernetes.GetClientInNamespace(restConfig, c.namespace)
+	if err != nil {
+		return err
+	}
+
+	c.cassManager = cassdcutil.NewManager(kubeClient)
+
+	return nil
+}
+
+// Validate ensures the flags are not conflicting
+func (c *options) Validate() error {
+	switch {
+	case len(c.params) == 0:
+		return errNoNodetoolArguments
+	case c.selector.Pod != "" && c.all:
+		return errPodAndAllUsedTogether
+	}
+	return c.selector.Validate()
+}
+
+// Run executes nodetool on the target pods
+func (c *options) Run() error {
+	ctx := context.Background()
+
+	dc, err := c.cassManager.CassandraDatacenter(ctx, c.dcName, c.namespace)
+	if err != nil {
+		return err
+	}
+
+	pods, err := c.targetPods(ctx, dc)
+	if err != nil {
+		return err
+	}
+
+	// The credentials are the same for every pod of the datacenter
+	var username, passwordFile string
+	if cassdcutil.JMXAuthEnabled(&pods[0]) {
+		var password string
+		username, password, err = c.cassManager.SuperuserCredentials(ctx, dc)
+		if err != nil {
+			return fmt.Errorf("JMX authentication is enabled, but the credentials are not available: %w", err)
+		}
+		passwordFile, err = cassdcutil.NodetoolPasswordFile(username, password)
+		if err != nil {
+			return err
+		}
+	}
+
+	if !c.all {
+		return c.exec(pods[0].Name, username, passwordFile, c.IOStreams)
+	}
+
+	return c.execAll(pods, username, passwordFile)
+}
+
+// targetPods returns the pods matching the selector ordered by preference, or with --all every ready pod
+func (c *options) targetPods(ctx context.Context, dc *cassdcapi.CassandraDatacenter) ([]corev1.Pod, error) {
+	if c.all {
+		return c.cassManager.ReadyPods(ctx, dc, c.selector)
+	}
+	return c.cassManager.SelectPods(ctx, dc, c.selector)
+}
+
+// exec runs nodetool in the pod, with JMX authentication the password is passed in a temporary file which is removed
+// once nodetool exits
+func (c *options) exec(podName, username, passwordFile string, streams genericclioptions.IOStreams) error {
+	if username == "" {
+		execOptions, err := util.GetExecOptions(streams, c.configFlags)
+		if err != nil {
+			return err
+		}
+
+		execOptions.PodName = podName
+		execOptions.Command = cassdcutil.NodetoolCommand(c.params, "", "")
+
+		return execOptions.Run()
+	}
+
+	secretExec := &util.SecretFileExec{
+		ConfigFlags: c.configFlags,
+		IOStreams:   genericclioptions.IOStreams{Out: streams.Out, ErrOut: streams.ErrOut},
+		PodName:     podName,
+		Name:        "nodetool-password",
+	}
+	return secretExec.Run(passwordFile, func(path string) []string {
+		return cassdcutil.NodetoolCommand(c.params, username, path)
+	})
+}
+
+// execAll runs the command on the pods in parallel and prints the output of each pod once all have finished
+func (c *options) execAll(pods []corev1.Pod, username, passwordFile string) error {
+	type result struct {
+		out bytes.Buffer
+		err error
+	}
+
+	results := make(map[string]*result, len(pods))
+	var wg sync.WaitGroup
+	for i := range pods {
+		podName := pods[i].Name
+		res := &result{}
+		results[podName] = res
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			// Merge the standard error to keep the messages next to the output they relate to
+			res.err = c.exec(podName, username, passwordFile, genericclioptions.IOStreams{Out: &res.out, ErrOut: &res.out})
+		}()
+	}
+	wg.Wait()
+
+	podNames := make([]string, 0, len(results))
+	for podName := range results {
+		podNames = append(podNames, podName)
+	}
+	sort.Strings(podNames)
+
+	failed := make([]string, 0)
+	for _, podName := range podNames {
+		res := results[podName]
+		fmt.Fprintf(c.Out, "=== %s ===\n", podName)
+		if _, err := c.Out.Write(res.out.Bytes()); err != nil {
+			return err
+		}
+		if res.err != nil {
+			fmt.Fprintf(c.Out, "error: %v\n", res.err)
+			failed = append(failed, podName)
+		}
+		fmt.Fprintln(c.Out)
+	}
+
+	if len(failed) > 0 {
+		return fmt.Errorf("nodetool failed on %d pod(s): %s", len(failed), strings.Join(failed, ", "))
+	}
+	return nil
+}
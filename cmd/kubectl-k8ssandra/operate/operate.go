@@ -2,12 +2,19 @@ package operate
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
+	"time"
 
+	controlapi "github.com/k8ssandra/cass-operator/apis/control/v1alpha1"
 	"github.com/k8ssandra/k8ssandra-client/pkg/cassdcutil"
 	"github.com/k8ssandra/k8ssandra-client/pkg/kubernetes"
+	"github.com/k8ssandra/k8ssandra-client/pkg/tasks"
+	"github.com/k8ssandra/k8ssandra-client/pkg/ui"
 	"github.com/spf13/cobra"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
+	watchtools "k8s.io/client-go/tools/watch"
 )
 
 var (
@@ -16,12 +23,27 @@ var (
 	%[1]s start <datacenter>
 	`
 
+	// rackScopeNote explains why start and stop have no --rack flag
+	rackScopeNote = "cass-operator only supports stopping and starting the whole datacenter, use restart --rack to restart a single rack"
+
 	stopExample = `
 	# shutdown an existing datacenter
 	%[1]s stop <datacenter>
 
 	# shutdown an existing datacenter and wait for all the pods to shutdown
 	%[1]s stop <datacenter> --wait
+
+	# shutdown an existing datacenter and wait at most 30 minutes for the pods to shutdown
+	%[1]s stop <datacenter> --wait --timeout 30m
+
+	# flush and drain every node before shutting down the datacenter
+	%[1]s stop <datacenter> --drain
+
+	# shutdown the datacenter even if some nodes fail to drain
+	%[1]s stop <datacenter> --drain --force
+
+	# show the change to the datacenter, validated by the API server, without shutting it down
+	%[1]s stop <datacenter> --dry-run=server
 	`
 
 	restartExample = `
@@ -30,6 +52,15 @@ var (
 
 	# request a rolling restart of a single rack called r1
 	%[1]s restart <datacenter> --rack r1
+
+	# request a rolling restart to start at 2am
+	%[1]s restart <datacenter> --schedule 02:00
+
+	# request a rolling restart even if some nodes are down or a keyspace would lose QUORUM
+	%[1]s restart <datacenter> --force
+
+	# follow a rolling restart someone else already requested, or request one if none is running
+	%[1]s restart <datacenter> --attach
 	`
 
 	errNoDatacenterDefined = fmt.Errorf("no target datacenter given")
@@ -39,16 +70,27 @@ var (
 type options struct {
 	configFlags *genericclioptions.ConfigFlags
 	genericclioptions.IOStreams
-	namespace   string
-	dcName      string
-	rackName    string
-	wait        bool
-	cassManager *cassdcutil.CassManager
+	namespace        string
+	dcName           string
+	rackName         string
+	wait             bool
+	attach           bool
+	timeout          time.Duration
+	size             int32
+	dryRun           string
+	dryRunMode       kubernetes.DryRunStrategy
+	force            bool
+	drain            bool
+	drainParallelism int
+	taskFlags        *tasks.TaskFlags
+	taskOptions      *tasks.TaskOptions
+	cassManager      *cassdcutil.CassManager
 }
 
 func newOptions(streams genericclioptions.IOStreams) *options {
 	return &options{
 		configFlags: genericclioptions.NewConfigFlags(true),
+		taskFlags:   tasks.NewTaskFlags(),
 		IOStreams:   streams,
 	}
 }
@@ -59,6 +101,7 @@ func NewStartCmd(streams genericclioptions.IOStreams) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:          "start [cluster]",
 		Short:        "restart an existing shutdown Cassandra cluster",
+		Long:         fmt.Sprintf("%s.\n\n%s.", "restart an existing shutdown Cassandra cluster", rackScopeNote),
 		Example:      fmt.Sprintf(startExample, "kubectl k8ssandra"),
 		SilenceUsage: true,
 		RunE: func(c *cobra.Command, args []string) error {
@@ -78,7 +121,8 @@ func NewStartCmd(streams genericclioptions.IOStreams) *cobra.Command {
 
 	fl := cmd.Flags()
 	fl.BoolVarP(&o.wait, "wait", "w", false, "wait until all pods have started")
-	fl.StringVar(&o.rackName, "rack", "", "restart only target rack")
+	fl.DurationVar(&o.timeout, "timeout", 0, "the length of time to wait before giving up, zero means wait forever")
+	kubernetes.AddDryRunFlag(fl, &o.dryRun)
 	o.configFlags.AddFlags(fl)
 	return cmd
 }
@@ -108,6 +152,12 @@ func NewRestartCmd(streams genericclioptions.IOStreams) *cobra.Command {
 
 	fl := cmd.Flags()
 	fl.BoolVarP(&o.wait, "wait", "w", false, "wait until all pods have restarted")
+	fl.StringVar(&o.rackName, "rack", "", "restart only target rack")
+	fl.BoolVar(&o.attach, "attach", false, "if a rolling restart is already running, wait for it instead of failing")
+	fl.BoolVar(&o.force, "force", false, "restart even if the availability preflight checks fail")
+	o.taskFlags.AddFlags(fl)
+	fl.DurationVar(&o.timeout, "timeout", 0, "the length of time to wait before giving up, zero means wait forever")
+	kubernetes.AddDryRunFlag(fl, &o.dryRun)
 	o.configFlags.AddFlags(fl)
 	return cmd
 }
@@ -118,6 +168,7 @@ func NewStopCmd(streams genericclioptions.IOStreams) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:          "stop [cluster]",
 		Short:        "shutdown running Cassandra cluster",
+		Long:         fmt.Sprintf("%s.\n\n%s.", "shutdown running Cassandra cluster", rackScopeNote),
 		Example:      fmt.Sprintf(stopExample, "kubectl k8ssandra"),
 		SilenceUsage: true,
 		RunE: func(c *cobra.Command, args []string) error {
@@ -137,6 +188,11 @@ func NewStopCmd(streams genericclioptions.IOStreams) *cobra.Command {
 
 	fl := cmd.Flags()
 	fl.BoolVarP(&o.wait, "wait", "w", false, "wait until all pods have terminated")
+	fl.BoolVar(&o.drain, "drain", false, "drain every node through the management API before stopping, the datacenter is not stopped if any node fails to drain")
+	fl.IntVar(&o.drainParallelism, "drain-parallelism", 4, "maximum number of nodes drained at the same time")
+	fl.BoolVar(&o.force, "force", false, "with --drain, stop even if some nodes fail to drain, the command still fails")
+	fl.DurationVar(&o.timeout, "timeout", 0, "the length of time to wait before giving up, zero means wait forever")
+	kubernetes.AddDryRunFlag(fl, &o.dryRun)
 	o.configFlags.AddFlags(fl)
 	return cmd
 }
@@ -151,6 +207,11 @@ func (c *options) Complete(cmd *cobra.Command, args []string) error {
 
 	c.dcName = args[0]
 
+	c.dryRunMode, err = kubernetes.ParseDryRun(c.dryRun)
+	if err != nil {
+		return err
+	}
+
 	c.namespace, _, err = c.configFlags.ToRawKubeConfigLoader().Namespace()
 	if err != nil {
 		return err
@@ -168,11 +229,23 @@ func (c *options) Complete(cmd *cobra.Command, args []string) error {
 
 	c.cassManager = cassdcutil.NewManager(kubeClient)
 
+	if c.dryRunMode != kubernetes.DryRunNone {
+		// Nothing changes, so there is nothing to wait for
+		c.cassManager.DryRun(c.dryRunMode, c.Out)
+		c.wait = false
+		c.attach = false
+	}
+
 	return nil
 }
 
-// Validate ensures that all required arguments and flag values are provided
+// Validate ensures that all required arguments and flag values are provided. Start and stop always target the whole
+// datacenter, CassandraDatacenter has no per rack stopped state.
 func (c *options) Validate() error {
+	if c.drain && c.drainParallelism < 1 {
+		return fmt.Errorf("--drain-parallelism must be at least 1")
+	}
+
 	// Verify target cluster exists
 	_, err := c.cassManager.CassandraDatacenter(context.Background(), c.dcName, c.namespace)
 	if err != nil {
@@ -184,6 +257,12 @@ func (c *options) Validate() error {
 
 // ValidateRestart ensures that all required arguments and flag values are provided
 func (c *options) ValidateRestart() error {
+	taskOptions, err := c.taskFlags.ToOptions(time.Now())
+	if err != nil {
+		return err
+	}
+	c.taskOptions = taskOptions
+
 	// Verify target cluster exists
 	dc, err := c.cassManager.CassandraDatacenter(context.Background(), c.dcName, c.namespace)
 	if err != nil {
@@ -193,15 +272,169 @@ func (c *options) ValidateRestart() error {
 	if dc.Spec.Stopped {
 		return errRestartingStopped
 	}
+	if c.rackName != "" {
+		for _, rack := range dc.GetRacks() {
+			if rack.Name == c.rackName {
+				return nil
+			}
+		}
+		return fmt.Errorf("rack %s does not exist in datacenter %s", c.rackName, c.dcName)
+	}
 	return nil
 }
 
 // Run either stops or starts the existing datacenter
 func (c *options) Run(stop bool) error {
-	return c.cassManager.ModifyStoppedState(context.Background(), c.dcName, c.namespace, stop, c.wait)
+	ctx, cancel := watchtools.ContextWithOptionalTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	if stop && c.drain {
+		return c.drainAndStop(ctx)
+	}
+
+	if !c.wait {
+		return c.cassManager.ModifyStoppedState(ctx, c.dcName, c.namespace, stop, false)
+	}
+
+	title := fmt.Sprintf("Starting datacenter %s", c.dcName)
+	if stop {
+		title = fmt.Sprintf("Stopping datacenter %s", c.dcName)
+	}
+
+	return c.runWithProgress(ctx, title, func(ctx context.Context) error {
+		return c.cassManager.ModifyStoppedState(ctx, c.dcName, c.namespace, stop, true)
+	})
+}
+
+// drainAndStop drains all the nodes before stopping the datacenter. If any node fails to drain, the datacenter is
+// left running and the *cassdcutil.DrainError is returned. With --force it is stopped regardless, leaving it running
+// would keep the already drained nodes out of service, but the DrainError is still returned.
+func (c *options) drainAndStop(ctx context.Context) error {
+	dc, err := c.cassManager.CassandraDatacenter(ctx, c.dcName, c.namespace)
+	if err != nil {
+		return err
+	}
+
+	if c.dryRunMode != kubernetes.DryRunNone {
+		if err := c.cassManager.DrainDatacenter(ctx, dc, c.drainParallelism, func(cassdcutil.ProgressEvent) {}); err != nil {
+			return err
+		}
+		return c.cassManager.ModifyStoppedState(ctx, c.dcName, c.namespace, true, false)
+	}
+
+	var drainErr *cassdcutil.DrainError
+	err = c.runWithProgressFunc(ctx, fmt.Sprintf("Draining and stopping datacenter %s", c.dcName), func(ctx context.Context, progress cassdcutil.ProgressFunc) error {
+		if err := c.cassManager.DrainDatacenter(ctx, dc, c.drainParallelism, progress); err != nil {
+			if !errors.As(err, &drainErr) {
+				return err
+			}
+			if !c.force {
+				return fmt.Errorf("refusing to stop datacenter %s, use --force to stop anyway: %w", c.dcName, err)
+			}
+		}
+
+		return c.cassManager.ModifyStoppedState(ctx, c.dcName, c.namespace, true, c.wait)
+	})
+	if err != nil {
+		return err
+	}
+
+	if drainErr != nil {
+		return fmt.Errorf("datacenter %s stopped without draining every node: %w", c.dcName, drainErr)
+	}
+
+	return nil
 }
 
 // Restart creates a restart task for the cluster
 func (c *options) Restart() error {
-	return c.cassManager.RestartDc(context.Background(), c.dcName, c.namespace, c.rackName, c.wait)
+	ctx, cancel := watchtools.ContextWithOptionalTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	if c.attach {
+		active, err := c.cassManager.ActiveTask(ctx, c.dcName, c.namespace, controlapi.CommandRestart)
+		if err != nil {
+			return err
+		}
+		if active != nil {
+			return c.runWithProgress(ctx, fmt.Sprintf("Attached to rolling restart %s of datacenter %s", active.Name, c.dcName), func(ctx context.Context) error {
+				return c.cassManager.WaitForTask(ctx, active)
+			})
+		}
+	}
+
+	pending, err := c.cassManager.PendingTasks(ctx, c.dcName, c.namespace, controlapi.CommandRestart)
+	if err != nil {
+		return err
+	}
+	for i := range pending {
+		fmt.Fprintf(c.ErrOut, "Pending: %s\n", tasks.DescribePendingTask(&pending[i]))
+	}
+
+	if !c.force {
+		dc, err := c.cassManager.CassandraDatacenter(ctx, c.dcName, c.namespace)
+		if err != nil {
+			return err
+		}
+		if err := c.cassManager.VerifyRestartSafety(ctx, dc); err != nil {
+			return fmt.Errorf("refusing to restart datacenter %s, use --force to restart anyway: %w", c.dcName, err)
+		}
+	}
+
+	if !c.wait && !c.attach {
+		return restartError(c.cassManager.RestartDc(ctx, c.dcName, c.namespace, c.rackName, c.taskOptions, false))
+	}
+
+	return c.runWithProgress(ctx, fmt.Sprintf("Restarting datacenter %s", c.dcName), func(ctx context.Context) error {
+		return restartError(c.cassManager.RestartDc(ctx, c.dcName, c.namespace, c.rackName, c.taskOptions, true))
+	})
+}
+
+// restartError adds a hint to attach to the running restart if one prevented creating a new one
+func restartError(err error) error {
+	var inProgress *tasks.TaskInProgressError
+	if errors.As(err, &inProgress) {
+		return fmt.Errorf("%w, use --attach to wait for it or --concurrency-policy=Allow to run next to it", err)
+	}
+	return err
+}
+
+// runWithProgress executes the operation while displaying the pod states, the datacenter condition transitions and
+// the Kubernetes events of the datacenter
+func (c *options) runWithProgress(ctx context.Context, title string, operation func(ctx context.Context) error) error {
+	return c.runWithProgressFunc(ctx, title, func(ctx context.Context, _ cassdcutil.ProgressFunc) error {
+		return operation(ctx)
+	})
+}
+
+// runWithProgressFunc is runWithProgress for operations which report progress events of their own
+func (c *options) runWithProgressFunc(ctx context.Context, title string, operation func(ctx context.Context, progress cassdcutil.ProgressFunc) error) error {
+	dc, err := c.cassManager.CassandraDatacenter(ctx, c.dcName, c.namespace)
+	if err != nil {
+		return err
+	}
+
+	return ui.RunWithProgress(ctx, c.Out, title, func(ctx context.Context, p ui.Progress) error {
+		watchCtx, stopWatch := context.WithCancel(ctx)
+		defer stopWatch()
+
+		progress := func(event cassdcutil.ProgressEvent) {
+			if event.Event != nil {
+				p.Event(cassdcutil.FormatEvent(event.Event))
+				return
+			}
+			if event.Condition != nil {
+				p.Event(strings.TrimSpace(fmt.Sprintf("%s=%s %s", event.Condition.Type, event.Condition.Status, event.Condition.Message)))
+				return
+			}
+			p.Update(event.Pod, string(event.PodState))
+			if event.Err != nil {
+				p.Event(fmt.Sprintf("%s: %v", event.Pod, event.Err))
+			}
+		}
+
+		go c.cassManager.WatchProgress(watchCtx, dc, c.rackName, progress)
+
+		return operation(ctx, progress)
+	})
 }
@@ -0,0 +1,88 @@
+package operate
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/k8ssandra/k8ssandra-client/pkg/kubernetes"
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	watchtools "k8s.io/client-go/tools/watch"
+)
+
+var (
+	scaleExample = `
+	# scale a datacenter to 6 nodes
+	%[1]s scale <datacenter> --size 6
+
+	# scale a datacenter to 6 nodes and wait until the new nodes have joined the ring
+	%[1]s scale <datacenter> --size 6 --wait
+	`
+
+	errNoSizeDefined = fmt.Errorf("target size must be given with --size")
+)
+
+func NewScaleCmd(streams genericclioptions.IOStreams) *cobra.Command {
+	o := newOptions(streams)
+
+	cmd := &cobra.Command{
+		Use:          "scale [cluster]",
+		Short:        "change the amount of nodes in a Cassandra datacenter",
+		Example:      fmt.Sprintf(scaleExample, "kubectl k8ssandra"),
+		SilenceUsage: true,
+		RunE: func(c *cobra.Command, args []string) error {
+			if err := o.Complete(c, args); err != nil {
+				return err
+			}
+			if err := o.ValidateScale(c); err != nil {
+				return err
+			}
+			if err := o.Scale(); err != nil {
+				return err
+			}
+
+			return nil
+		},
+	}
+
+	fl := cmd.Flags()
+	fl.Int32Var(&o.size, "size", 0, "new size of the datacenter, must be a multiple of the rack count")
+	fl.BoolVarP(&o.wait, "wait", "w", false, "wait until the datacenter has been scaled")
+	fl.DurationVar(&o.timeout, "timeout", 0, "the length of time to wait before giving up, zero means wait forever")
+	kubernetes.AddDryRunFlag(fl, &o.dryRun)
+	o.configFlags.AddFlags(fl)
+	return cmd
+}
+
+// ValidateScale ensures the target size is set and allowed for the datacenter
+func (c *options) ValidateScale(cmd *cobra.Command) error {
+	if !cmd.Flags().Changed("size") {
+		return errNoSizeDefined
+	}
+
+	ctx := context.Background()
+	dc, err := c.cassManager.CassandraDatacenter(ctx, c.dcName, c.namespace)
+	if err != nil {
+		return err
+	}
+
+	if dc.Spec.Stopped {
+		return fmt.Errorf("unable to scale a stopped datacenter")
+	}
+
+	return c.cassManager.ValidateScale(ctx, dc, c.size)
+}
+
+// Scale modifies the size of the datacenter
+func (c *options) Scale() error {
+	ctx, cancel := watchtools.ContextWithOptionalTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	if !c.wait {
+		return c.cassManager.ScaleDatacenter(ctx, c.dcName, c.namespace, c.size, false)
+	}
+
+	return c.runWithProgress(ctx, fmt.Sprintf("Scaling datacenter %s to %d nodes", c.dcName, c.size), func(ctx context.Context) error {
+		return c.cassManager.ScaleDatacenter(ctx, c.dcName, c.namespace, c.size, true)
+	})
+}
@@ -0,0 +1,117 @@
+package operate
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/k8ssandra/k8ssandra-client/pkg/kubernetes"
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	watchtools "k8s.io/client-go/tools/watch"
+)
+
+var (
+	upgradeExample = `
+	# upgrade the Cassandra version of a datacenter
+	%[1]s upgrade-cassandra <datacenter> --version 4.1.0
+
+	# upgrade the Cassandra version, wait for the rolling update and then upgrade the SSTables
+	%[1]s upgrade-cassandra <datacenter> --version 4.1.0 --upgradesstables
+	`
+
+	errNoVersionDefined = fmt.Errorf("target version must be given with --version")
+)
+
+type upgradeOptions struct {
+	*options
+	version         string
+	image           string
+	upgradeSSTables bool
+}
+
+func NewUpgradeCmd(streams genericclioptions.IOStreams) *cobra.Command {
+	o := &upgradeOptions{options: newOptions(streams)}
+
+	cmd := &cobra.Command{
+		Use:          "upgrade-cassandra [cluster]",
+		Short:        "upgrade the server version of a Cassandra datacenter",
+		Example:      fmt.Sprintf(upgradeExample, "kubectl k8ssandra"),
+		SilenceUsage: true,
+		RunE: func(c *cobra.Command, args []string) error {
+			if err := o.Complete(c, args); err != nil {
+				return err
+			}
+			if err := o.Validate(); err != nil {
+				return err
+			}
+			if err := o.Run(); err != nil {
+				return err
+			}
+
+			return nil
+		},
+	}
+
+	fl := cmd.Flags()
+	fl.StringVar(&o.version, "version", "", "target server version")
+	fl.StringVar(&o.image, "image", "", "server image to use instead of the default image of the version")
+	fl.BoolVar(&o.upgradeSSTables, "upgradesstables", false, "run upgradesstables on all the nodes after the upgrade, implies --wait")
+	fl.BoolVarP(&o.wait, "wait", "w", false, "wait until all pods have been upgraded")
+	fl.DurationVar(&o.timeout, "timeout", 0, "the length of time to wait before giving up, zero means wait forever")
+	kubernetes.AddDryRunFlag(fl, &o.dryRun)
+	o.configFlags.AddFlags(fl)
+	return cmd
+}
+
+// Validate ensures the version is given and the datacenter is ready to be upgraded
+func (c *upgradeOptions) Validate() error {
+	if c.version == "" {
+		return errNoVersionDefined
+	}
+
+	ctx := context.Background()
+	dc, err := c.cassManager.CassandraDatacenter(ctx, c.dcName, c.namespace)
+	if err != nil {
+		return err
+	}
+
+	if dc.Spec.Stopped {
+		return fmt.Errorf("unable to upgrade a stopped datacenter")
+	}
+
+	return c.cassManager.ValidateUpgrade(ctx, dc, c.version)
+}
+
+// Run updates the server version and optionally upgrades the SSTables once all the nodes run the new version
+func (c *upgradeOptions) Run() error {
+	ctx, cancel := watchtools.ContextWithOptionalTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	if c.dryRunMode != kubernetes.DryRunNone {
+		if err := c.cassManager.UpgradeServerVersion(ctx, c.dcName, c.namespace, c.version, c.image, false); err != nil {
+			return err
+		}
+		if c.upgradeSSTables {
+			return c.cassManager.UpgradeSSTables(ctx, c.dcName, c.namespace, false)
+		}
+		return nil
+	}
+
+	if !c.wait && !c.upgradeSSTables {
+		return c.cassManager.UpgradeServerVersion(ctx, c.dcName, c.namespace, c.version, c.image, false)
+	}
+
+	err := c.runWithProgress(ctx, fmt.Sprintf("Upgrading datacenter %s to version %s", c.dcName, c.version), func(ctx context.Context) error {
+		return c.cassManager.UpgradeServerVersion(ctx, c.dcName, c.namespace, c.version, c.image, true)
+	})
+	if err != nil {
+		return err
+	}
+
+	if c.upgradeSSTables {
+		fmt.Fprintf(c.Out, "Running upgradesstables on datacenter %s\n", c.dcName)
+		return c.cassManager.UpgradeSSTables(ctx, c.dcName, c.namespace, true)
+	}
+
+	return nil
+}
@@ -0,0 +1,136 @@
+package portforward
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"strconv"
+
+	"github.com/k8ssandra/k8ssandra-client/pkg/cassdcutil"
+	"github.com/k8ssandra/k8ssandra-client/pkg/kubernetes"
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/rest"
+)
+
+var (
+	portForwardExample = `
+	# forward the CQL port 9042 to a ready pod of the datacenter
+	%[1]s port-forward <datacenter>
+
+	# forward the management API and the metrics ports
+	%[1]s port-forward <datacenter> --mgmt --metrics
+
+	# accept connections from other hosts as well
+	%[1]s port-forward <datacenter> --cql --address 0.0.0.0
+	`
+
+	errNoDatacenterDefined = fmt.Errorf("no target datacenter given")
+)
+
+type options struct {
+	configFlags *genericclioptions.ConfigFlags
+	genericclioptions.IOStreams
+	namespace   string
+	dcName      string
+	cql         bool
+	mgmt        bool
+	metrics     bool
+	addresses   []string
+	ports       []string
+	restConfig  *rest.Config
+	cassManager *cassdcutil.CassManager
+}
+
+func newOptions(streams genericclioptions.IOStreams) *options {
+	return &options{
+		configFlags: genericclioptions.NewConfigFlags(true),
+		IOStreams:   streams,
+	}
+}
+
+// NewCmd provides a cobra command wrapping options
+func NewCmd(streams genericclioptions.IOStreams) *cobra.Command {
+	o := newOptions(streams)
+
+	cmd := &cobra.Command{
+		Use:          "port-forward [datacenter]",
+		Short:        "forward local ports to a ready pod of a datacenter, switching pods when the pod goes away",
+		Example:      fmt.Sprintf(portForwardExample, "kubectl k8ssandra"),
+		SilenceUsage: true,
+		RunE: func(c *cobra.Command, args []string) error {
+			if err := o.Complete(c, args); err != nil {
+				return err
+			}
+			if err := o.Run(); err != nil {
+				return err
+			}
+
+			return nil
+		},
+	}
+
+	fl := cmd.Flags()
+	fl.BoolVar(&o.cql, "cql", false, fmt.Sprintf("forward the CQL port %d, the default if no port is selected", cassdcutil.CQLPort))
+	fl.BoolVar(&o.mgmt, "mgmt", false, fmt.Sprintf("forward the management API port %d", cassdcutil.ManagementPort))
+	fl.BoolVar(&o.metrics, "metrics", false, fmt.Sprintf("forward the metrics port %d", cassdcutil.MetricsPort))
+	fl.StringSliceVar(&o.addresses, "address", []string{"localhost"}, "addresses to listen on (comma separated), only accepts IP addresses or localhost")
+	o.configFlags.AddFlags(fl)
+	return cmd
+}
+
+// Complete parses the arguments and necessary flags to options
+func (c *options) Complete(cmd *cobra.Command, args []string) error {
+	var err error
+
+	if len(args) < 1 {
+		return errNoDatacenterDefined
+	}
+
+	c.dcName = args[0]
+
+	if !c.cql && !c.mgmt && !c.metrics {
+		c.cql = true
+	}
+	for _, port := range []struct {
+		enabled bool
+		number  int
+	}{{c.cql, cassdcutil.CQLPort}, {c.mgmt, cassdcutil.ManagementPort}, {c.metrics, cassdcutil.MetricsPort}} {
+		if port.enabled {
+			c.ports = append(c.ports, strconv.Itoa(port.number))
+		}
+	}
+
+	c.namespace, _, err = c.configFlags.ToRawKubeConfigLoader().Namespace()
+	if err != nil {
+		return err
+	}
+
+	c.restConfig, err = c.configFlags.ToRESTConfig()
+	if err != nil {
+		return err
+	}
+
+	kubeClient, err := kubernetes.GetClientInNamespace(c.restConfig, c.namespace)
+	if err != nil {
+		return err
+	}
+
+	c.cassManager = cassdcutil.NewManager(kubeClient)
+
+	return nil
+}
+
+// Run forwards the ports until interrupted
+func (c *options) Run() error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	dc, err := c.cassManager.CassandraDatacenter(ctx, c.dcName, c.namespace)
+	if err != nil {
+		return err
+	}
+
+	return c.cassManager.ForwardPorts(ctx, c.restConfig, dc, c.addresses, c.ports, c.Out)
+}
@@ -0,0 +1,144 @@
+package status
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/k8ssandra/k8ssandra-client/pkg/cassdcutil"
+	"github.com/k8ssandra/k8ssandra-client/pkg/kubernetes"
+	"github.com/k8ssandra/k8ssandra-client/pkg/ui"
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/cli-runtime/pkg/printers"
+)
+
+var (
+	statusExample = `
+	# show the conditions, pods and Cassandra node states of a datacenter
+	%[1]s status <datacenter>
+	`
+
+	errNoDatacenterDefined = fmt.Errorf("no target datacenter given")
+)
+
+type options struct {
+	configFlags *genericclioptions.ConfigFlags
+	genericclioptions.IOStreams
+	namespace   string
+	dcName      string
+	cassManager *cassdcutil.CassManager
+}
+
+func newOptions(streams genericclioptions.IOStreams) *options {
+	return &options{
+		configFlags: genericclioptions.NewConfigFlags(true),
+		IOStreams:   streams,
+	}
+}
+
+// NewCmd provides a cobra command wrapping options
+func NewCmd(streams genericclioptions.IOStreams) *cobra.Command {
+	o := newOptions(streams)
+
+	cmd := &cobra.Command{
+		Use:          "status [datacenter]",
+		Short:        "summarize the state of a CassandraDatacenter",
+		Example:      fmt.Sprintf(statusExample, "kubectl k8ssandra"),
+		SilenceUsage: true,
+		RunE: func(c *cobra.Command, args []string) error {
+			if err := o.Complete(c, args); err != nil {
+				return err
+			}
+			if err := o.Run(); err != nil {
+				return err
+			}
+
+			return nil
+		},
+	}
+
+	o.configFlags.AddFlags(cmd.Flags())
+	return cmd
+}
+
+// Complete parses the arguments and necessary flags to options
+func (c *options) Complete(cmd *cobra.Command, args []string) error {
+	var err error
+
+	if len(args) < 1 {
+		return errNoDatacenterDefined
+	}
+
+	c.dcName = args[0]
+
+	c.namespace, _, err = c.configFlags.ToRawKubeConfigLoader().Namespace()
+	if err != nil {
+		return err
+	}
+
+	restConfig, err := c.configFlags.ToRESTConfig()
+	if err != nil {
+		return err
+	}
+
+	kubeClient, err := kubernetes.GetClientInNamespace(restConfig, c.namespace)
+	if err != nil {
+		return err
+	}
+
+	c.cassManager = cassdcutil.NewManager(kubeClient)
+
+	return nil
+}
+
+// Run fetches the datacenter status and prints it
+func (c *options) Run() error {
+	status, err := c.cassManager.DatacenterStatus(context.Background(), c.dcName, c.namespace)
+	if err != nil {
+		return err
+	}
+
+	w := printers.GetNewTabWriter(c.Out)
+
+	fmt.Fprintf(w, "Datacenter:\t%s/%s\n", status.Namespace, status.Name)
+	fmt.Fprintf(w, "Cluster:\t%s\n", status.ClusterName)
+	fmt.Fprintf(w, "Server:\t%s %s\n", status.ServerType, status.ServerVersion)
+	fmt.Fprintf(w, "Size:\t%d\n", status.Size)
+	fmt.Fprintf(w, "Stopped:\t%t\n", status.Stopped)
+	fmt.Fprintf(w, "Operator progress:\t%s\n", status.Progress)
+	fmt.Fprintln(w)
+
+	fmt.Fprintln(w, "CONDITION\tSTATUS\tLAST TRANSITION\tMESSAGE")
+	for _, condition := range status.Conditions {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", condition.Type, condition.Status, condition.LastTransitionTime.Format("2006-01-02 15:04:05"), condition.Message)
+	}
+	fmt.Fprintln(w)
+
+	fmt.Fprintln(w, "RACK\tPOD\tREADY\tPHASE\tNODE STATE\tCASSANDRA\tVERSION")
+	for _, rack := range status.Racks {
+		if len(rack.Pods) == 0 {
+			fmt.Fprintf(w, "%s\t<none>\t\t\t\t\t\n", rack.Name)
+			continue
+		}
+		for _, pod := range rack.Pods {
+			fmt.Fprintf(w, "%s\t%s\t%t\t%s\t%s\t%s\t%s\n", rack.Name, pod.Name, pod.Ready, pod.Phase, ui.ValueOrNone(pod.NodeState), ui.ValueOrNone(pod.CassandraState), ui.ValueOrNone(pod.ReleaseVersion))
+		}
+	}
+
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	racks := make([]string, 0, len(status.Racks))
+	for _, rack := range status.Racks {
+		racks = append(racks, fmt.Sprintf("%s %d/%d", rack.Name, rack.Ready, len(rack.Pods)))
+	}
+	fmt.Fprintf(c.Out, "\nReady pods per rack: %s\n", strings.Join(racks, ", "))
+
+	if status.NodeStateErr != nil {
+		fmt.Fprintf(c.ErrOut, "Cassandra node states are not available: %v\n", status.NodeStateErr)
+	}
+
+	return nil
+}
@@ -0,0 +1,246 @@
+package task
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	controlapi "github.com/k8ssandra/cass-operator/apis/control/v1alpha1"
+	"github.com/k8ssandra/k8ssandra-client/pkg/cassdcutil"
+	"github.com/k8ssandra/k8ssandra-client/pkg/kubernetes"
+	"github.com/k8ssandra/k8ssandra-client/pkg/tasks"
+	"github.com/k8ssandra/k8ssandra-client/pkg/ui"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	watchtools "k8s.io/client-go/tools/watch"
+)
+
+var (
+	applyExample = `
+	# upgrade the SSTables and then run cleanup, one job after another
+	%[1]s task apply <datacenter> --job upgradesstables --job cleanup,keyspace=<keyspace>
+
+	# run the jobs of a CassandraTask manifest in order
+	%[1]s task apply -f maintenance.yaml
+
+	# show the CassandraTasks the manifest would create
+	%[1]s task apply -f maintenance.yaml --dry-run
+	`
+
+	errNoJobsDefined      = fmt.Errorf("give the jobs with --job or a CassandraTask manifest with --filename")
+	errJobSourceAmbiguous = fmt.Errorf("--job and --filename can not be used together")
+)
+
+type applyOptions struct {
+	configFlags *genericclioptions.ConfigFlags
+	genericclioptions.IOStreams
+	namespace   string
+	dcName      string
+	filename    string
+	jobFlags    []string
+	jobs        []controlapi.CassandraJob
+	taskFlags   *tasks.TaskFlags
+	taskOptions *tasks.TaskOptions
+	timeout     time.Duration
+	dryRun      string
+	dryRunMode  kubernetes.DryRunStrategy
+	cassManager *cassdcutil.CassManager
+}
+
+func newApplyOptions(streams genericclioptions.IOStreams) *applyOptions {
+	return &applyOptions{
+		configFlags: genericclioptions.NewConfigFlags(true),
+		taskFlags:   tasks.NewTaskFlags(),
+		IOStreams:   streams,
+	}
+}
+
+// NewApplyCmd provides a cobra command which runs a pipeline of CassandraTask jobs
+func NewApplyCmd(streams genericclioptions.IOStreams) *cobra.Command {
+	o := newApplyOptions(streams)
+
+	cmd := &cobra.Command{
+		Use:          "apply [datacenter]",
+		Short:        "run multiple CassandraTask jobs on a datacenter in order and wait for them to complete",
+		Example:      fmt.Sprintf(applyExample, "kubectl k8ssandra"),
+		SilenceUsage: true,
+		RunE: func(c *cobra.Command, args []string) error {
+			if err := o.Complete(c, args); err != nil {
+				return err
+			}
+			if err := o.Validate(); err != nil {
+				return err
+			}
+			if err := o.Run(); err != nil {
+				return err
+			}
+
+			return nil
+		},
+	}
+
+	fl := cmd.Flags()
+	fl.StringVarP(&o.filename, "filename", "f", "", "CassandraTask manifest with the jobs to run")
+	fl.StringArrayVar(&o.jobFlags, "job", nil, "job to run as command[,keyspace=|source-datacenter=|pod=|rack=value], can be repeated")
+	o.taskFlags.AddFlags(fl)
+	kubernetes.AddDryRunFlag(fl, &o.dryRun)
+	fl.DurationVar(&o.timeout, "timeout", 0, "the length of time to wait before giving up, zero means wait forever")
+	o.configFlags.AddFlags(fl)
+	return cmd
+}
+
+// Complete parses the arguments, the jobs and necessary flags to options
+func (c *applyOptions) Complete(cmd *cobra.Command, args []string) error {
+	var err error
+
+	if len(args) > 0 {
+		c.dcName = args[0]
+	}
+
+	c.namespace, _, err = c.configFlags.ToRawKubeConfigLoader().Namespace()
+	if err != nil {
+		return err
+	}
+
+	c.dryRunMode, err = kubernetes.ParseDryRun(c.dryRun)
+	if err != nil {
+		return err
+	}
+
+	c.taskOptions, err = c.taskFlags.ToOptions(time.Now())
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case c.filename != "" && len(c.jobFlags) > 0:
+		return errJobSourceAmbiguous
+	case c.filename != "":
+		if err := c.readManifest(cmd); err != nil {
+			return err
+		}
+	case len(c.jobFlags) > 0:
+		for _, definition := range c.jobFlags {
+			job, err := tasks.ParseJob(definition)
+			if err != nil {
+				return err
+			}
+			c.jobs = append(c.jobs, job)
+		}
+	default:
+		return errNoJobsDefined
+	}
+
+	if c.dcName == "" {
+		return errNoDatacenterDefined
+	}
+
+	restConfig, err := c.configFlags.ToRESTConfig()
+	if err != nil {
+		return err
+	}
+
+	kubeClient, err := kubernetes.GetClientInNamespace(restConfig, c.namespace)
+	if err != nil {
+		return err
+	}
+
+	c.cassManager = cassdcutil.NewManager(kubeClient)
+	c.cassManager.DryRun(c.dryRunMode, c.Out)
+
+	return nil
+}
+
+// readManifest takes the jobs, the datacenter and the settings not overridden by flags from the manifest
+func (c *applyOptions) readManifest(cmd *cobra.Command) error {
+	data, err := os.ReadFile(c.filename)
+	if err != nil {
+		return err
+	}
+
+	task, err := tasks.ParseTaskManifest(data)
+	if err != nil {
+		return err
+	}
+
+	for _, namespace := range []string{task.Namespace, task.Spec.Datacenter.Namespace} {
+		if namespace != "" && namespace != c.namespace {
+			return fmt.Errorf("the CassandraTask targets namespace %s, use --namespace %s", namespace, namespace)
+		}
+	}
+
+	if dcName := task.Spec.Datacenter.Name; dcName != "" {
+		if c.dcName != "" && c.dcName != dcName {
+			return fmt.Errorf("the CassandraTask targets datacenter %s, not %s", dcName, c.dcName)
+		}
+		c.dcName = dcName
+	}
+
+	c.jobs = task.Spec.Jobs
+
+	if !cmd.Flags().Changed("schedule") && task.Spec.ScheduledTime != nil {
+		scheduledTime := task.Spec.ScheduledTime.Time
+		c.taskOptions.ScheduledTime = &scheduledTime
+	}
+	if !cmd.Flags().Changed("ttl") {
+		c.taskOptions.TTLSecondsAfterFinished = task.Spec.TTLSecondsAfterFinished
+	}
+	if !cmd.Flags().Changed("concurrency-policy") {
+		c.taskOptions.ConcurrencyPolicy = task.Spec.ConcurrencyPolicy
+	}
+
+	return nil
+}
+
+// Validate ensures the datacenter exists and is running
+func (c *applyOptions) Validate() error {
+	dc, err := c.cassManager.CassandraDatacenter(context.Background(), c.dcName, c.namespace)
+	if err != nil {
+		return err
+	}
+
+	if dc.Spec.Stopped {
+		return fmt.Errorf("unable to run tasks on a stopped datacenter")
+	}
+
+	return nil
+}
+
+// Run executes the jobs one after another while showing the state of each job and the events of the datacenter
+func (c *applyOptions) Run() error {
+	ctx, cancel := watchtools.ContextWithOptionalTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	if c.dryRunMode != kubernetes.DryRunNone {
+		// Only the tasks are shown, there is no progress to follow
+		return c.cassManager.RunPipeline(ctx, c.dcName, c.namespace, c.jobs, c.taskOptions, func(cassdcutil.PipelineEvent) {})
+	}
+
+	dc, err := c.cassManager.CassandraDatacenter(ctx, c.dcName, c.namespace)
+	if err != nil {
+		return err
+	}
+
+	title := fmt.Sprintf("Running %d jobs on datacenter %s", len(c.jobs), c.dcName)
+	return ui.RunWithProgress(ctx, c.Out, title, func(ctx context.Context, p ui.Progress) error {
+		watchCtx, stopWatch := context.WithCancel(ctx)
+		defer stopWatch()
+
+		go c.cassManager.WatchEvents(watchCtx, dc, time.Now(), func(event *corev1.Event) {
+			p.Event(cassdcutil.FormatEvent(event))
+		})
+
+		return c.cassManager.RunPipeline(ctx, c.dcName, c.namespace, c.jobs, c.taskOptions, func(event cassdcutil.PipelineEvent) {
+			status := string(event.State)
+			if event.Task != "" {
+				status = fmt.Sprintf("%s (%s)", status, event.Task)
+			}
+			p.Update(event.Job, status)
+			if event.Err != nil {
+				p.Event(fmt.Sprintf("%s: %v", event.Job, event.Err))
+			}
+		})
+	})
+}
@@ -0,0 +1,97 @@
+package task
+
+import (
+	"context"
+	"fmt"
+
+	controlapi "github.com/k8ssandra/cass-operator/apis/control/v1alpha1"
+	"github.com/k8ssandra/k8ssandra-client/pkg/tasks"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+var (
+	deleteExample = `
+	# delete a task
+	%[1]s task delete <task>
+
+	# delete all the tasks of a datacenter
+	%[1]s task delete --all --dc <datacenter>
+	`
+
+	errDeleteTargetAmbiguous = fmt.Errorf("give either task names or --all")
+)
+
+type deleteOptions struct {
+	*options
+	all bool
+}
+
+// NewDeleteCmd provides a cobra command which deletes CassandraTasks
+func NewDeleteCmd(streams genericclioptions.IOStreams) *cobra.Command {
+	o := &deleteOptions{options: newOptions(streams)}
+
+	cmd := &cobra.Command{
+		Use:          "delete [task]...",
+		Short:        "delete CassandraTasks",
+		Example:      fmt.Sprintf(deleteExample, "kubectl k8ssandra"),
+		SilenceUsage: true,
+		RunE: func(c *cobra.Command, args []string) error {
+			if err := o.Complete(c, args); err != nil {
+				return err
+			}
+			if err := o.Validate(); err != nil {
+				return err
+			}
+			if err := o.Delete(); err != nil {
+				return err
+			}
+
+			return nil
+		},
+	}
+
+	fl := cmd.Flags()
+	fl.BoolVar(&o.all, "all", false, "delete all the tasks in the namespace")
+	fl.StringVar(&o.dcName, "dc", "", "with --all, delete only the tasks targeting this datacenter")
+	o.configFlags.AddFlags(fl)
+	return cmd
+}
+
+// Validate ensures the tasks to delete are given exactly one way
+func (c *deleteOptions) Validate() error {
+	if c.all == (len(c.taskNames) > 0) {
+		return errDeleteTargetAmbiguous
+	}
+	if c.dcName != "" && !c.all {
+		return fmt.Errorf("--dc can only be used with --all")
+	}
+	return nil
+}
+
+// Delete removes the selected tasks
+func (c *deleteOptions) Delete() error {
+	ctx := context.Background()
+
+	names := c.taskNames
+	if c.all {
+		taskList, err := tasks.ListTasks(ctx, c.kubeClient, c.dcName)
+		if err != nil {
+			return err
+		}
+		for _, task := range taskList.Items {
+			names = append(names, task.Name)
+		}
+	}
+
+	for _, name := range names {
+		task := &controlapi.CassandraTask{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: c.namespace}}
+		if err := c.kubeClient.Delete(ctx, task); err != nil {
+			return err
+		}
+		fmt.Fprintf(c.Out, "CassandraTask %s deleted\n", name)
+	}
+
+	return nil
+}
@@ -0,0 +1,101 @@
+package task
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	controlapi "github.com/k8ssandra/cass-operator/apis/control/v1alpha1"
+	"github.com/k8ssandra/k8ssandra-client/pkg/ui"
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/cli-runtime/pkg/printers"
+)
+
+var (
+	describeExample = `
+	# show the jobs, progress and conditions of a task
+	%[1]s task describe <task>
+
+	# print the task as JSON
+	%[1]s task describe <task> -o json
+	`
+
+	errNoTaskDefined = fmt.Errorf("no task name given")
+)
+
+// NewDescribeCmd provides a cobra command which shows the details of a CassandraTask
+func NewDescribeCmd(streams genericclioptions.IOStreams) *cobra.Command {
+	o := newOptions(streams)
+
+	cmd := &cobra.Command{
+		Use:          "describe [task]",
+		Short:        "show the details of a CassandraTask",
+		Example:      fmt.Sprintf(describeExample, "kubectl k8ssandra"),
+		SilenceUsage: true,
+		RunE: func(c *cobra.Command, args []string) error {
+			if err := o.Complete(c, args); err != nil {
+				return err
+			}
+			if err := o.Describe(); err != nil {
+				return err
+			}
+
+			return nil
+		},
+	}
+
+	o.printFlags.AddFlags(cmd)
+	o.configFlags.AddFlags(cmd.Flags())
+	return cmd
+}
+
+// Describe prints the task details or the task in the requested output format
+func (c *options) Describe() error {
+	if len(c.taskNames) != 1 {
+		return errNoTaskDefined
+	}
+
+	task := &controlapi.CassandraTask{}
+	if err := c.kubeClient.Get(context.Background(), types.NamespacedName{Name: c.taskNames[0], Namespace: c.namespace}, task); err != nil {
+		return err
+	}
+
+	if c.structuredOutput() {
+		return c.printObject(task)
+	}
+
+	w := printers.GetNewTabWriter(c.Out)
+
+	fmt.Fprintf(w, "Task:\t%s/%s\n", task.Namespace, task.Name)
+	fmt.Fprintf(w, "Datacenter:\t%s\n", task.Spec.Datacenter.Name)
+	fmt.Fprintf(w, "Created:\t%s\n", formatTime(&task.CreationTimestamp))
+	fmt.Fprintf(w, "Scheduled:\t%s\n", formatTime(task.Spec.ScheduledTime))
+	fmt.Fprintf(w, "Concurrency policy:\t%s\n", ui.ValueOrNone(string(task.Spec.ConcurrencyPolicy)))
+	if task.Spec.TTLSecondsAfterFinished != nil {
+		fmt.Fprintf(w, "TTL after finished:\t%s\n", time.Duration(*task.Spec.TTLSecondsAfterFinished)*time.Second)
+	}
+	fmt.Fprintf(w, "Started:\t%s\n", formatTime(task.Status.StartTime))
+	fmt.Fprintf(w, "Completed:\t%s\n", formatTime(task.Status.CompletionTime))
+	fmt.Fprintf(w, "Active:\t%d\n", task.Status.Active)
+	fmt.Fprintf(w, "Succeeded:\t%d\n", task.Status.Succeeded)
+	fmt.Fprintf(w, "Failed:\t%d\n", task.Status.Failed)
+	fmt.Fprintln(w)
+
+	fmt.Fprintln(w, "JOB\tCOMMAND\tKEYSPACE\tSOURCE DATACENTER\tPOD\tRACK")
+	for _, job := range task.Spec.Jobs {
+		args := job.Arguments
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", job.Name, job.Command, ui.ValueOrNone(args.KeyspaceName),
+			ui.ValueOrNone(args.SourceDatacenter), ui.ValueOrNone(args.PodName), ui.ValueOrNone(args.RackName))
+	}
+	fmt.Fprintln(w)
+
+	fmt.Fprintln(w, "CONDITION\tSTATUS\tLAST TRANSITION\tREASON\tMESSAGE")
+	for _, condition := range task.Status.Conditions {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", condition.Type, condition.Status, formatTime(&condition.LastTransitionTime),
+			ui.ValueOrNone(condition.Reason), condition.Message)
+	}
+
+	return w.Flush()
+}
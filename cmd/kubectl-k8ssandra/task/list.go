@@ -0,0 +1,102 @@
+package task
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	controlapi "github.com/k8ssandra/cass-operator/apis/control/v1alpha1"
+	"github.com/k8ssandra/k8ssandra-client/pkg/tasks"
+	"github.com/k8ssandra/k8ssandra-client/pkg/ui"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/duration"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/cli-runtime/pkg/printers"
+)
+
+var (
+	listExample = `
+	# list all the CassandraTasks in the namespace
+	%[1]s task list
+
+	# list the tasks of a single datacenter
+	%[1]s task list --dc <datacenter>
+
+	# list the tasks as YAML
+	%[1]s task list -o yaml
+	`
+)
+
+// NewListCmd provides a cobra command which lists the CassandraTasks
+func NewListCmd(streams genericclioptions.IOStreams) *cobra.Command {
+	o := newOptions(streams)
+
+	cmd := &cobra.Command{
+		Use:          "list",
+		Short:        "list CassandraTasks and their progress",
+		Example:      fmt.Sprintf(listExample, "kubectl k8ssandra"),
+		SilenceUsage: true,
+		RunE: func(c *cobra.Command, args []string) error {
+			if err := o.Complete(c, args); err != nil {
+				return err
+			}
+			if err := o.List(); err != nil {
+				return err
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&o.dcName, "dc", "", "list only the tasks targeting this datacenter")
+	o.printFlags.AddFlags(cmd)
+	o.configFlags.AddFlags(cmd.Flags())
+	return cmd
+}
+
+// List prints the tasks as a table or in the requested output format
+func (c *options) List() error {
+	taskList, err := tasks.ListTasks(context.Background(), c.kubeClient, c.dcName)
+	if err != nil {
+		return err
+	}
+
+	if c.structuredOutput() {
+		for i := range taskList.Items {
+			taskList.Items[i].SetGroupVersionKind(controlapi.GroupVersion.WithKind("CassandraTask"))
+		}
+		return c.printObject(taskList)
+	}
+
+	if len(taskList.Items) == 0 {
+		fmt.Fprintf(c.ErrOut, "No CassandraTasks found in %s namespace.\n", c.namespace)
+		return nil
+	}
+
+	w := printers.GetNewTabWriter(c.Out)
+	fmt.Fprintln(w, "NAME\tDATACENTER\tJOBS\tINITIATOR\tSTARTED\tCOMPLETED\tSUCCEEDED\tFAILED\tAGE")
+	for _, task := range taskList.Items {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%d\t%d\t%s\n", task.Name, task.Spec.Datacenter.Name, jobCommands(&task), ui.ValueOrNone(task.Labels[tasks.InitiatorLabel]),
+			formatTime(task.Status.StartTime), formatTime(task.Status.CompletionTime), task.Status.Succeeded, task.Status.Failed,
+			duration.HumanDuration(time.Since(task.CreationTimestamp.Time)))
+	}
+
+	return w.Flush()
+}
+
+func jobCommands(task *controlapi.CassandraTask) string {
+	commands := make([]string, 0, len(task.Spec.Jobs))
+	for _, job := range task.Spec.Jobs {
+		commands = append(commands, string(job.Command))
+	}
+	return strings.Join(commands, ",")
+}
+
+func formatTime(t *metav1.Time) string {
+	if t == nil {
+		return "<none>"
+	}
+	return t.Format("2006-01-02 15:04:05")
+}
@@ -0,0 +1,275 @@
+package task
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	controlapi "github.com/k8ssandra/cass-operator/apis/control/v1alpha1"
+	"github.com/k8ssandra/k8ssandra-client/pkg/cassdcutil"
+	"github.com/k8ssandra/k8ssandra-client/pkg/kubernetes"
+	"github.com/k8ssandra/k8ssandra-client/pkg/tasks"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	watchtools "k8s.io/client-go/tools/watch"
+)
+
+var (
+	runExample = `
+	# run cleanup on every node of the datacenter
+	%[1]s task run cleanup <datacenter>
+
+	# run cleanup for a single keyspace and wait for it to finish
+	%[1]s task run cleanup <datacenter> --keyspace <keyspace> --wait
+
+	# rebuild the nodes of the datacenter by streaming data from another datacenter
+	%[1]s task run rebuild <datacenter> --source-datacenter <datacenter>
+
+	# replace the node running in the given pod
+	%[1]s task run replacenode <datacenter> --pod <pod>
+
+	# restart a single rack
+	%[1]s task run restart <datacenter> --rack <rack>
+
+	# run cleanup at 2am and remove the finished task after 12 hours
+	%[1]s task run cleanup <datacenter> --schedule 02:00 --ttl 12h
+
+	# show the CassandraTask that would be created
+	%[1]s task run cleanup <datacenter> --dry-run=server
+
+	# wait for an already running cleanup instead of failing, or start a new one if none is running
+	%[1]s task run cleanup <datacenter> --attach
+	`
+
+	errNoCommandDefined    = fmt.Errorf("no task command given")
+	errNoDatacenterDefined = fmt.Errorf("no target datacenter given")
+)
+
+type runOptions struct {
+	configFlags *genericclioptions.ConfigFlags
+	genericclioptions.IOStreams
+	namespace   string
+	dcName      string
+	command     controlapi.CassandraCommand
+	args        controlapi.JobArguments
+	taskFlags   *tasks.TaskFlags
+	taskOptions *tasks.TaskOptions
+	wait        bool
+	attach      bool
+	dryRun      string
+	dryRunMode  kubernetes.DryRunStrategy
+	timeout     time.Duration
+	cassManager *cassdcutil.CassManager
+}
+
+func newRunOptions(streams genericclioptions.IOStreams) *runOptions {
+	return &runOptions{
+		configFlags: genericclioptions.NewConfigFlags(true),
+		taskFlags:   tasks.NewTaskFlags(),
+		IOStreams:   streams,
+	}
+}
+
+// NewRunCmd provides a cobra command which creates a CassandraTask
+func NewRunCmd(streams genericclioptions.IOStreams) *cobra.Command {
+	o := newRunOptions(streams)
+
+	cmd := &cobra.Command{
+		Use:          "run [command] [datacenter]",
+		Short:        fmt.Sprintf("run a CassandraTask command on a datacenter, one of: %s", strings.Join(tasks.SupportedCommands(), ", ")),
+		Long:         fmt.Sprintf("Run a CassandraTask command on a datacenter.\n\n%s", tasks.CommandsHelp()),
+		Example:      fmt.Sprintf(runExample, "kubectl k8ssandra"),
+		SilenceUsage: true,
+		RunE: func(c *cobra.Command, args []string) error {
+			if err := o.Complete(c, args); err != nil {
+				return err
+			}
+			if err := o.Validate(); err != nil {
+				return err
+			}
+			if err := o.Run(); err != nil {
+				return err
+			}
+
+			return nil
+		},
+	}
+
+	fl := cmd.Flags()
+	fl.StringVar(&o.args.KeyspaceName, "keyspace", "", "target keyspace (cleanup, upgradesstables)")
+	fl.StringVar(&o.args.SourceDatacenter, "source-datacenter", "", "datacenter to stream the data from (rebuild)")
+	fl.StringVar(&o.args.PodName, "pod", "", "pod running the node to replace (replacenode)")
+	fl.StringVar(&o.args.RackName, "rack", "", "target rack (restart)")
+	fl.BoolVarP(&o.wait, "wait", "w", false, "wait until the task has completed")
+	fl.BoolVar(&o.attach, "attach", false, "if the command is already running on the datacenter, wait for that task instead of failing")
+	o.taskFlags.AddFlags(fl)
+	kubernetes.AddDryRunFlag(fl, &o.dryRun)
+	fl.DurationVar(&o.timeout, "timeout", 0, "the length of time to wait before giving up, zero means wait forever")
+	o.configFlags.AddFlags(fl)
+	return cmd
+}
+
+// Complete parses the arguments and necessary flags to options
+func (c *runOptions) Complete(cmd *cobra.Command, args []string) error {
+	var err error
+
+	if len(args) < 1 {
+		return errNoCommandDefined
+	}
+
+	if len(args) < 2 {
+		return errNoDatacenterDefined
+	}
+
+	c.command, err = tasks.ParseCommand(args[0])
+	if err != nil {
+		return err
+	}
+
+	c.dcName = args[1]
+
+	c.dryRunMode, err = kubernetes.ParseDryRun(c.dryRun)
+	if err != nil {
+		return err
+	}
+
+	c.namespace, _, err = c.configFlags.ToRawKubeConfigLoader().Namespace()
+	if err != nil {
+		return err
+	}
+
+	restConfig, err := c.configFlags.ToRESTConfig()
+	if err != nil {
+		return err
+	}
+
+	kubeClient, err := kubernetes.GetClientInNamespace(restConfig, c.namespace)
+	if err != nil {
+		return err
+	}
+
+	c.cassManager = cassdcutil.NewManager(kubeClient)
+
+	if c.dryRunMode != kubernetes.DryRunNone {
+		c.cassManager.DryRun(c.dryRunMode, c.Out)
+		c.wait = false
+		c.attach = false
+	}
+
+	return nil
+}
+
+// Validate ensures the arguments are valid for the command and the targets exist
+func (c *runOptions) Validate() error {
+	if err := tasks.ValidateArguments(c.command, &c.args); err != nil {
+		return err
+	}
+
+	taskOptions, err := c.taskFlags.ToOptions(time.Now())
+	if err != nil {
+		return err
+	}
+	c.taskOptions = taskOptions
+
+	dc, err := c.cassManager.CassandraDatacenter(context.Background(), c.dcName, c.namespace)
+	if err != nil {
+		return err
+	}
+
+	if dc.Spec.Stopped {
+		return fmt.Errorf("unable to run %s on a stopped datacenter", c.command)
+	}
+
+	if c.args.RackName != "" {
+		for _, rack := range dc.GetRacks() {
+			if rack.Name == c.args.RackName {
+				return nil
+			}
+		}
+		return fmt.Errorf("rack %s does not exist in datacenter %s", c.args.RackName, c.dcName)
+	}
+
+	return nil
+}
+
+// Run creates the task and optionally waits for it to complete while printing the events of the datacenter
+func (c *runOptions) Run() error {
+	ctx, cancel := watchtools.ContextWithOptionalTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	if c.attach {
+		active, err := c.cassManager.ActiveTask(ctx, c.dcName, c.namespace, c.command)
+		if err != nil {
+			return err
+		}
+		if active != nil {
+			fmt.Fprintf(c.Out, "Attached to running CassandraTask %s\n", active.Name)
+			if err := c.followEvents(ctx); err != nil {
+				return err
+			}
+			if err := c.cassManager.WaitForTask(ctx, active); err != nil {
+				return err
+			}
+			fmt.Fprintf(c.Out, "CassandraTask %s completed\n", active.Name)
+			return nil
+		}
+	}
+
+	if err := c.reportPendingTasks(ctx); err != nil {
+		return err
+	}
+
+	wait := c.wait || c.attach
+	if wait {
+		if err := c.followEvents(ctx); err != nil {
+			return err
+		}
+	}
+
+	task, err := c.cassManager.RunTask(ctx, c.dcName, c.namespace, c.command, &c.args, c.taskOptions, wait)
+	if task != nil && c.dryRunMode == kubernetes.DryRunNone {
+		fmt.Fprintf(c.Out, "CassandraTask %s created\n", task.Name)
+	}
+	if err != nil {
+		var inProgress *tasks.TaskInProgressError
+		if errors.As(err, &inProgress) {
+			return fmt.Errorf("%w, use --attach to wait for it or --concurrency-policy=Allow to run next to it", err)
+		}
+		return err
+	}
+
+	if wait {
+		fmt.Fprintf(c.Out, "CassandraTask %s completed\n", task.Name)
+	}
+
+	return nil
+}
+
+// reportPendingTasks lists the tasks with the same command which wait for their scheduled time, they do not prevent
+// creating a new task
+func (c *runOptions) reportPendingTasks(ctx context.Context) error {
+	pending, err := c.cassManager.PendingTasks(ctx, c.dcName, c.namespace, c.command)
+	if err != nil {
+		return err
+	}
+	for i := range pending {
+		fmt.Fprintf(c.ErrOut, "Pending: %s\n", tasks.DescribePendingTask(&pending[i]))
+	}
+	return nil
+}
+
+// followEvents prints the Kubernetes events of the datacenter until the context is done
+func (c *runOptions) followEvents(ctx context.Context) error {
+	dc, err := c.cassManager.CassandraDatacenter(ctx, c.dcName, c.namespace)
+	if err != nil {
+		return err
+	}
+
+	go c.cassManager.WatchEvents(ctx, dc, time.Now(), func(event *corev1.Event) {
+		fmt.Fprintln(c.Out, cassdcutil.FormatEvent(event))
+	})
+	return nil
+}
@@ -0,0 +1,100 @@
+package task
+
+import (
+	"github.com/k8ssandra/k8ssandra-client/pkg/kubernetes"
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+type ClientOptions struct {
+	configFlags *genericclioptions.ConfigFlags
+	genericclioptions.IOStreams
+}
+
+// NewClientOptions provides an instance of ClientOptions with default values
+func NewClientOptions(streams genericclioptions.IOStreams) *ClientOptions {
+	return &ClientOptions{
+		configFlags: genericclioptions.NewConfigFlags(true),
+		IOStreams:   streams,
+	}
+}
+
+// NewCmd provides a cobra command wrapping ClientOptions
+func NewCmd(streams genericclioptions.IOStreams) *cobra.Command {
+	o := NewClientOptions(streams)
+
+	cmd := &cobra.Command{
+		Use: "task [subcommand] [flags]",
+	}
+
+	// Add subcommands
+	cmd.AddCommand(NewRunCmd(streams))
+	cmd.AddCommand(NewApplyCmd(streams))
+	cmd.AddCommand(NewListCmd(streams))
+	cmd.AddCommand(NewDescribeCmd(streams))
+	cmd.AddCommand(NewDeleteCmd(streams))
+	o.configFlags.AddFlags(cmd.Flags())
+
+	return cmd
+}
+
+// options are shared by the subcommands which read or delete existing tasks
+type options struct {
+	configFlags *genericclioptions.ConfigFlags
+	printFlags  *genericclioptions.PrintFlags
+	genericclioptions.IOStreams
+	namespace  string
+	dcName     string
+	taskNames  []string
+	kubeClient kubernetes.NamespacedClient
+}
+
+func newOptions(streams genericclioptions.IOStreams) *options {
+	return &options{
+		configFlags: genericclioptions.NewConfigFlags(true),
+		printFlags:  genericclioptions.NewPrintFlags(""),
+		IOStreams:   streams,
+	}
+}
+
+// Complete parses the arguments and necessary flags to options
+func (c *options) Complete(cmd *cobra.Command, args []string) error {
+	var err error
+
+	c.taskNames = args
+
+	c.namespace, _, err = c.configFlags.ToRawKubeConfigLoader().Namespace()
+	if err != nil {
+		return err
+	}
+
+	restConfig, err := c.configFlags.ToRESTConfig()
+	if err != nil {
+		return err
+	}
+
+	c.kubeClient, err = kubernetes.GetClientInNamespace(restConfig, c.namespace)
+	if err != nil {
+		return err
+	}
+
+	// JSON and YAML output needs the apiVersion and kind, which the client does not fill in
+	c.printFlags.WithTypeSetter(c.kubeClient.Scheme())
+
+	return nil
+}
+
+// structuredOutput returns true if the user requested the objects to be printed instead of the table
+func (c *options) structuredOutput() bool {
+	return c.printFlags.OutputFormat != nil && *c.printFlags.OutputFormat != ""
+}
+
+// printObject prints the object in the requested output format
+func (c *options) printObject(obj runtime.Object) error {
+	printer, err := c.printFlags.ToPrinter()
+	if err != nil {
+		return err
+	}
+	return printer.PrintObj(obj, c.Out)
+}
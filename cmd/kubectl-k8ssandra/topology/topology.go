@@ -0,0 +1,129 @@
+package topology
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/k8ssandra/k8ssandra-client/pkg/cassdcutil"
+	"github.com/k8ssandra/k8ssandra-client/pkg/kubernetes"
+	"github.com/k8ssandra/k8ssandra-client/pkg/ui"
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/cli-runtime/pkg/printers"
+)
+
+var (
+	topologyExample = `
+	# show the Kubernetes nodes and zones the pods of each rack run on
+	%[1]s topology <datacenter>
+	`
+
+	errNoDatacenterDefined = fmt.Errorf("no target datacenter given")
+)
+
+type options struct {
+	configFlags *genericclioptions.ConfigFlags
+	genericclioptions.IOStreams
+	namespace   string
+	dcName      string
+	cassManager *cassdcutil.CassManager
+}
+
+func newOptions(streams genericclioptions.IOStreams) *options {
+	return &options{
+		configFlags: genericclioptions.NewConfigFlags(true),
+		IOStreams:   streams,
+	}
+}
+
+// NewCmd provides a cobra command wrapping options
+func NewCmd(streams genericclioptions.IOStreams) *cobra.Command {
+	o := newOptions(streams)
+
+	cmd := &cobra.Command{
+		Use:          "topology [datacenter]",
+		Short:        "show which Kubernetes node and zone each Cassandra pod of a datacenter runs on",
+		Example:      fmt.Sprintf(topologyExample, "kubectl k8ssandra"),
+		SilenceUsage: true,
+		RunE: func(c *cobra.Command, args []string) error {
+			if err := o.Complete(c, args); err != nil {
+				return err
+			}
+			if err := o.Run(); err != nil {
+				return err
+			}
+
+			return nil
+		},
+	}
+
+	o.configFlags.AddFlags(cmd.Flags())
+	return cmd
+}
+
+// Complete parses the arguments and necessary flags to options
+func (c *options) Complete(cmd *cobra.Command, args []string) error {
+	var err error
+
+	if len(args) < 1 {
+		return errNoDatacenterDefined
+	}
+
+	c.dcName = args[0]
+
+	c.namespace, _, err = c.configFlags.ToRawKubeConfigLoader().Namespace()
+	if err != nil {
+		return err
+	}
+
+	restConfig, err := c.configFlags.ToRESTConfig()
+	if err != nil {
+		return err
+	}
+
+	kubeClient, err := kubernetes.GetClientInNamespace(restConfig, c.namespace)
+	if err != nil {
+		return err
+	}
+
+	c.cassManager = cassdcutil.NewManager(kubeClient)
+
+	return nil
+}
+
+// Run prints the placement of the pods per rack followed by the warnings about it
+func (c *options) Run() error {
+	topology, err := c.cassManager.DatacenterTopology(context.Background(), c.dcName, c.namespace)
+	if err != nil {
+		return err
+	}
+
+	w := printers.GetNewTabWriter(c.Out)
+
+	fmt.Fprintln(w, "RACK\tPOD\tNODE\tZONE\tHOST IP\tSTATE")
+	for _, rack := range topology.Racks {
+		if len(rack.Pods) == 0 {
+			fmt.Fprintf(w, "%s\t<none>\t\t\t\t\n", rack.Name)
+			continue
+		}
+		for _, pod := range rack.Pods {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", rack.Name, pod.Name, ui.ValueOrNone(pod.Node), ui.ValueOrNone(pod.Zone), ui.ValueOrNone(pod.HostIP), ui.ValueOrNone(pod.RingState))
+		}
+	}
+
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	if topology.NodeErr != nil {
+		fmt.Fprintf(c.ErrOut, "Kubernetes nodes are not available, zones are not shown: %v\n", topology.NodeErr)
+	}
+	if topology.NodeStateErr != nil {
+		fmt.Fprintf(c.ErrOut, "Cassandra node states are not available: %v\n", topology.NodeStateErr)
+	}
+	for _, warning := range topology.Warnings {
+		fmt.Fprintf(c.ErrOut, "Warning: %s\n", warning)
+	}
+
+	return nil
+}
@@ -5,7 +5,9 @@ import (
 	"fmt"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/k8ssandra/k8ssandra-client/pkg/cassdcutil"
 	"github.com/k8ssandra/k8ssandra-client/pkg/kubernetes"
+	"github.com/k8ssandra/k8ssandra-client/pkg/secrets"
 	"github.com/k8ssandra/k8ssandra-client/pkg/ui"
 	"github.com/k8ssandra/k8ssandra-client/pkg/users"
 	"github.com/spf13/cobra"
@@ -19,6 +21,12 @@ var (
 
 	# Add new superusers to CassandraDatacenter dc1 from a path /tmp/users.txt
 	%[1]s add --dc dc1 --path /tmp/users.txt --superuser
+
+	# Show the users which would be added from a path /tmp/users.txt
+	%[1]s add --dc dc1 --path /tmp/users.txt --dry-run
+
+	# Add the users through a pod of rack r1
+	%[1]s add --dc dc1 --path /tmp/users.txt --rack r1
 	`
 	errNoDcDc           = fmt.Errorf("target CassandraDatacenter is required")
 	errDoubleDefinition = fmt.Errorf("either --path or --username is allowed, not both")
@@ -30,6 +38,7 @@ type addOptions struct {
 	genericclioptions.IOStreams
 	namespace  string
 	datacenter string
+	selector   cassdcutil.PodSelector
 	superuser  bool
 
 	// For manual entering from CLI
@@ -38,6 +47,9 @@ type addOptions struct {
 
 	// When reading from files
 	secretPath string
+
+	dryRun     string
+	dryRunMode kubernetes.DryRunStrategy
 }
 
 func newAddOptions(streams genericclioptions.IOStreams) *addOptions {
@@ -76,6 +88,8 @@ func NewAddCmd(streams genericclioptions.IOStreams) *cobra.Command {
 	fl.BoolVar(&o.superuser, "superuser", true, "create users as superusers")
 	fl.StringVarP(&o.username, "username", "u", "", "username to add")
 	fl.StringVarP(&o.password, "password", "p", "", "password to set for the user")
+	cassdcutil.AddPodSelectorFlags(fl, &o.selector)
+	kubernetes.AddDryRunFlag(fl, &o.dryRun)
 	o.configFlags.AddFlags(fl)
 	return cmd
 }
@@ -89,7 +103,8 @@ func (c *addOptions) Complete(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	return nil
+	c.dryRunMode, err = kubernetes.ParseDryRun(c.dryRun)
+	return err
 }
 
 // Validate ensures that all required arguments and flag values are provided
@@ -106,7 +121,7 @@ func (c *addOptions) Validate() error {
 		return errMissingUsername
 	}
 
-	return nil
+	return c.selector.Validate()
 }
 
 // Run processes the input, creates a connection to Kubernetes and processes a secret to add the users
@@ -123,8 +138,12 @@ func (c *addOptions) Run() error {
 
 	ctx := context.Background()
 
+	if c.dryRunMode != kubernetes.DryRunNone {
+		return c.describe(ctx, kubeClient)
+	}
+
 	if c.secretPath != "" {
-		return users.AddNewUsersFromSecret(ctx, kubeClient, c.datacenter, c.secretPath, c.superuser)
+		return users.AddNewUsersFromSecret(ctx, kubeClient, c.datacenter, c.selector, c.secretPath, c.superuser)
 	}
 
 	// Interactive prompt
@@ -155,5 +174,34 @@ func (c *addOptions) Run() error {
 		}
 	}
 
-	return users.AddNewUser(ctx, kubeClient, c.datacenter, c.username, c.password, c.superuser)
+	return users.AddNewUser(ctx, kubeClient, c.datacenter, c.selector, c.username, c.password, c.superuser)
+}
+
+// describe shows the roles which would be created without creating them
+func (c *addOptions) describe(ctx context.Context, kubeClient kubernetes.NamespacedClient) error {
+	if c.dryRunMode == kubernetes.DryRunServer {
+		fmt.Fprintln(c.ErrOut, "Roles are created through the management API, which has no server side dry-run, showing the client side result")
+	}
+
+	usernames := make([]string, 0, 1)
+	if c.secretPath != "" {
+		entries, err := secrets.ReadTargetPath(c.secretPath)
+		if err != nil {
+			return err
+		}
+		for user := range entries {
+			usernames = append(usernames, user)
+		}
+	} else {
+		if c.username == "" {
+			userPrompt := ui.NewPrompt("Username")
+			if _, err := tea.NewProgram(ui.NewPrompter([]*ui.Prompt{userPrompt})).Run(); err != nil {
+				return err
+			}
+			c.username = userPrompt.Value()
+		}
+		usernames = append(usernames, c.username)
+	}
+
+	return users.DescribeNewUsers(ctx, kubeClient, c.datacenter, c.selector, usernames, c.superuser, c.Out)
 }
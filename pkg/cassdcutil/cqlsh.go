@@ -0,0 +1,20 @@
+package cassdcutil
+
+import (
+	"fmt"
+	"strings"
+)
+
+// CqlshRC returns a cqlshrc file authenticating with the credentials. The password is never passed on the cqlsh
+// command line, which would make it visible in the process list and in the audit log of the exec request.
+func CqlshRC(username, password string) (string, error) {
+	if strings.ContainsAny(username+password, "\r\n") {
+		return "", fmt.Errorf("credentials containing line breaks can not be used with cqlsh")
+	}
+	return fmt.Sprintf("[authentication]\nusername = %s\npassword = %s\n", username, password), nil
+}
+
+// CqlshCommand returns the cqlsh command line using the cqlshrc file at rcPath
+func CqlshCommand(rcPath string, args []string) []string {
+	return append([]string{"cqlsh", "--cqlshrc", rcPath}, args...)
+}
@@ -0,0 +1,20 @@
+package cassdcutil
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCqlshRC(t *testing.T) {
+	require := require.New(t)
+
+	rc, err := CqlshRC("admin", "p%ss=word")
+	require.NoError(err)
+	require.Equal("[authentication]\nusername = admin\npassword = p%ss=word\n", rc)
+
+	_, err = CqlshRC("admin", "pass\nusername = other")
+	require.Error(err)
+
+	require.Equal([]string{"cqlsh", "--cqlshrc", "/tmp/rc", "-e", "select now() from system.local"}, CqlshCommand("/tmp/rc", []string{"-e", "select now() from system.local"}))
+}
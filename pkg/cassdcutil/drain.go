@@ -0,0 +1,107 @@
+package cassdcutil
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	cassdcapi "github.com/k8ssandra/cass-operator/apis/cassandra/v1beta1"
+	"github.com/k8ssandra/k8ssandra-client/pkg/kubernetes"
+	"github.com/k8ssandra/k8ssandra-client/pkg/mgmtapi"
+)
+
+// DrainError lists the pods whose Cassandra node could not be drained
+type DrainError struct {
+	Failures map[string]error
+}
+
+func (e *DrainError) Error() string {
+	pods := make([]string, 0, len(e.Failures))
+	for pod := range e.Failures {
+		pods = append(pods, pod)
+	}
+	sort.Strings(pods)
+
+	failures := make([]string, 0, len(pods))
+	for _, pod := range pods {
+		failures = append(failures, fmt.Sprintf("%s: %v", pod, e.Failures[pod]))
+	}
+	return fmt.Sprintf("unable to drain %d pod(s): %s", len(failures), strings.Join(failures, ", "))
+}
+
+// DrainDatacenter drains the Cassandra node of every ready pod in the datacenter through the management API, which
+// also flushes the memtables. At most parallelism pods are drained at the same time. Every pod is attempted and a
+// *DrainError lists the ones that failed. Pods which are not ready are reported as skipped.
+func (c *CassManager) DrainDatacenter(ctx context.Context, cassdc *cassdcapi.CassandraDatacenter, parallelism int, progress ProgressFunc) error {
+	if parallelism < 1 {
+		return fmt.Errorf("drain parallelism must be at least 1")
+	}
+
+	podList, err := c.CassandraDatacenterPods(ctx, cassdc)
+	if err != nil {
+		return err
+	}
+
+	if c.dryRun != kubernetes.DryRunNone {
+		for i := range podList.Items {
+			if isPodReady(&podList.Items[i]) {
+				fmt.Fprintf(c.dryRunOut, "Pod %s would be drained (dry run: %s)\n", podList.Items[i].Name, c.dryRun)
+			}
+		}
+		return nil
+	}
+
+	mgmtClient, err := mgmtapi.NewManagementClient(ctx, c.client, cassdc)
+	if err != nil {
+		return err
+	}
+
+	var (
+		mu       sync.Mutex
+		wg       sync.WaitGroup
+		failures = make(map[string]error)
+		slots    = make(chan struct{}, parallelism)
+	)
+
+	for i := range podList.Items {
+		pod := &podList.Items[i]
+		if !isPodReady(pod) {
+			progress(ProgressEvent{Pod: pod.Name, PodState: PodDrainSkipped})
+			continue
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			select {
+			case slots <- struct{}{}:
+				defer func() { <-slots }()
+			case <-ctx.Done():
+				mu.Lock()
+				failures[pod.Name] = ctx.Err()
+				mu.Unlock()
+				return
+			}
+
+			progress(ProgressEvent{Pod: pod.Name, PodState: PodDraining})
+			if err := mgmtClient.CallDrainEndpoint(pod); err != nil {
+				progress(ProgressEvent{Pod: pod.Name, PodState: PodDrainFailed, Err: err})
+				mu.Lock()
+				failures[pod.Name] = err
+				mu.Unlock()
+				return
+			}
+			progress(ProgressEvent{Pod: pod.Name, PodState: PodDrained})
+		}()
+	}
+
+	wg.Wait()
+
+	if len(failures) > 0 {
+		return &DrainError{Failures: failures}
+	}
+	return nil
+}
@@ -0,0 +1,131 @@
+package cassdcutil
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	cassdcapi "github.com/k8ssandra/cass-operator/apis/cassandra/v1beta1"
+	"github.com/k8ssandra/k8ssandra-client/pkg/kubernetes"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// serverDataPrefix is the prefix cass-operator gives the PersistentVolumeClaims of the pods
+const serverDataPrefix = "server-data-"
+
+// EventFunc receives the Kubernetes events observed by WatchEvents
+type EventFunc func(event *corev1.Event)
+
+// IsDatacenterEvent returns true if the event is about the CassandraDatacenter or one of its StatefulSets, pods or
+// PersistentVolumeClaims. The StatefulSets and the objects derived from them are recognized by their name, which
+// cass-operator derives from the cluster and datacenter names.
+func IsDatacenterEvent(cassdc *cassdcapi.CassandraDatacenter, event *corev1.Event) bool {
+	involved := event.InvolvedObject
+	if involved.Namespace != "" && involved.Namespace != cassdc.Namespace {
+		return false
+	}
+
+	prefix := cassdcapi.CleanupForKubernetes(cassdc.Spec.ClusterName+"-"+cassdc.Name) + "-"
+
+	switch involved.Kind {
+	case "CassandraDatacenter":
+		return involved.Name == cassdc.Name
+	case "StatefulSet", "Pod":
+		return strings.HasPrefix(involved.Name, prefix)
+	case "PersistentVolumeClaim":
+		return strings.HasPrefix(involved.Name, serverDataPrefix+prefix)
+	default:
+		return false
+	}
+}
+
+// EventTime returns the last time the event was observed
+func EventTime(event *corev1.Event) time.Time {
+	switch {
+	case !event.LastTimestamp.IsZero():
+		return event.LastTimestamp.Time
+	case !event.EventTime.IsZero():
+		return event.EventTime.Time
+	default:
+		return event.CreationTimestamp.Time
+	}
+}
+
+// FormatEvent returns a single line description of the event
+func FormatEvent(event *corev1.Event) string {
+	line := fmt.Sprintf("%s %s %s/%s: %s", event.Type, event.Reason, strings.ToLower(event.InvolvedObject.Kind), event.InvolvedObject.Name, strings.TrimSpace(event.Message))
+	if event.Count > 1 {
+		line = fmt.Sprintf("%s (x%d)", line, event.Count)
+	}
+	return line
+}
+
+// ListEvents returns the Kubernetes events of the CassandraDatacenter and its StatefulSets, pods and
+// PersistentVolumeClaims, oldest first
+func (c *CassManager) ListEvents(ctx context.Context, cassdc *cassdcapi.CassandraDatacenter) ([]corev1.Event, error) {
+	eventList := &corev1.EventList{}
+	if err := c.client.List(ctx, eventList, client.InNamespace(cassdc.Namespace)); err != nil {
+		return nil, err
+	}
+
+	events := make([]corev1.Event, 0, len(eventList.Items))
+	for i := range eventList.Items {
+		if IsDatacenterEvent(cassdc, &eventList.Items[i]) {
+			events = append(events, eventList.Items[i])
+		}
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		return EventTime(&events[i]).Before(EventTime(&events[j]))
+	})
+
+	return events, nil
+}
+
+// WatchEvents calls handler for the Kubernetes events of the datacenter observed after since, including repeats of
+// older events, until the context is done
+func (c *CassManager) WatchEvents(ctx context.Context, cassdc *cassdcapi.CassandraDatacenter, since time.Time, handler EventFunc) {
+	// The event timestamps only have a precision of seconds
+	c.watchEvents(ctx, cassdc, since.Truncate(time.Second), make(map[types.UID]int32), handler)
+}
+
+// FollowEvents continues after ListEvents and calls handler for the Kubernetes events of the datacenter which are not
+// in listed, and for the ones in listed once they are repeated, until the context is done
+func (c *CassManager) FollowEvents(ctx context.Context, cassdc *cassdcapi.CassandraDatacenter, listed []corev1.Event, handler EventFunc) {
+	counts := make(map[types.UID]int32, len(listed))
+	for i := range listed {
+		counts[listed[i].UID] = listed[i].Count
+	}
+	c.watchEvents(ctx, cassdc, time.Time{}, counts, handler)
+}
+
+// watchEvents calls handler for the events of the datacenter not older than since, an event is only reported again
+// if its count differs from the one in counts
+func (c *CassManager) watchEvents(ctx context.Context, cassdc *cassdcapi.CassandraDatacenter, since time.Time, counts map[types.UID]int32, handler EventFunc) {
+	// The handlers are called from the informer's goroutine only
+	update := func(obj interface{}) {
+		event, ok := obj.(*corev1.Event)
+		if !ok || !IsDatacenterEvent(cassdc, event) || EventTime(event).Before(since) {
+			return
+		}
+
+		if count, seen := counts[event.UID]; seen && count == event.Count {
+			return
+		}
+		counts[event.UID] = event.Count
+		handler(event)
+	}
+
+	eventLw := kubernetes.NewListWatch(ctx, c.client, &corev1.EventList{}, client.InNamespace(cassdc.Namespace))
+	_, eventInformer := cache.NewInformer(eventLw, &corev1.Event{}, 0, cache.ResourceEventHandlerFuncs{
+		AddFunc:    update,
+		UpdateFunc: func(_, obj interface{}) { update(obj) },
+	})
+
+	eventInformer.Run(ctx.Done())
+}
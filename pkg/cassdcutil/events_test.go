@@ -0,0 +1,91 @@
+package cassdcutil
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	cassdcapi "github.com/k8ssandra/cass-operator/apis/cassandra/v1beta1"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestIsDatacenterEvent(t *testing.T) {
+	require := require.New(t)
+
+	cassdc := &cassdcapi.CassandraDatacenter{
+		ObjectMeta: metav1.ObjectMeta{Name: "dc1", Namespace: "cass"},
+		Spec:       cassdcapi.CassandraDatacenterSpec{ClusterName: "Test Cluster"},
+	}
+
+	event := func(kind, namespace, name string) *corev1.Event {
+		return &corev1.Event{InvolvedObject: corev1.ObjectReference{Kind: kind, Namespace: namespace, Name: name}}
+	}
+
+	require.True(IsDatacenterEvent(cassdc, event("CassandraDatacenter", "cass", "dc1")))
+	require.True(IsDatacenterEvent(cassdc, event("StatefulSet", "cass", "testcluster-dc1-r1-sts")))
+	require.True(IsDatacenterEvent(cassdc, event("Pod", "cass", "testcluster-dc1-r1-sts-0")))
+	require.True(IsDatacenterEvent(cassdc, event("PersistentVolumeClaim", "cass", "server-data-testcluster-dc1-r1-sts-0")))
+
+	require.False(IsDatacenterEvent(cassdc, event("CassandraDatacenter", "cass", "dc2")))
+	require.False(IsDatacenterEvent(cassdc, event("CassandraDatacenter", "other", "dc1")))
+	require.False(IsDatacenterEvent(cassdc, event("Pod", "cass", "testcluster-dc10-r1-sts-0")))
+	require.False(IsDatacenterEvent(cassdc, event("Service", "cass", "testcluster-dc1-service")))
+}
+
+func TestFormatEvent(t *testing.T) {
+	require := require.New(t)
+
+	event := &corev1.Event{
+		InvolvedObject: corev1.ObjectReference{Kind: "Pod", Name: "testcluster-dc1-r1-sts-0"},
+		Type:           corev1.EventTypeWarning,
+		Reason:         "FailedScheduling",
+		Message:        "0/3 nodes are available\n",
+		Count:          1,
+	}
+	require.Equal("Warning FailedScheduling pod/testcluster-dc1-r1-sts-0: 0/3 nodes are available", FormatEvent(event))
+
+	event.Count = 4
+	require.Equal("Warning FailedScheduling pod/testcluster-dc1-r1-sts-0: 0/3 nodes are available (x4)", FormatEvent(event))
+}
+
+func TestFollowEvents(t *testing.T) {
+	require := require.New(t)
+
+	cassdc := &cassdcapi.CassandraDatacenter{
+		ObjectMeta: metav1.ObjectMeta{Name: "dc1", Namespace: "cass"},
+		Spec:       cassdcapi.CassandraDatacenterSpec{ClusterName: "Test Cluster"},
+	}
+
+	now := metav1.Now()
+	event := func(name string, count int32) *corev1.Event {
+		return &corev1.Event{
+			ObjectMeta:     metav1.ObjectMeta{Name: name, Namespace: "cass", UID: types.UID(name)},
+			InvolvedObject: corev1.ObjectReference{Kind: "Pod", Namespace: "cass", Name: "testcluster-dc1-r1-sts-0"},
+			LastTimestamp:  now,
+			Count:          count,
+		}
+	}
+
+	scheme := runtime.NewScheme()
+	require.NoError(clientgoscheme.AddToScheme(scheme))
+	cassManager := NewManager(fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(event("printed", 1), event("repeated", 2), event("new", 1)).Build())
+
+	listed := []corev1.Event{*event("printed", 1), *event("repeated", 1)}
+
+	// The informer delivers the existing events right after it has started
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	followed := make([]string, 0)
+	cassManager.FollowEvents(ctx, cassdc, listed, func(event *corev1.Event) {
+		followed = append(followed, event.Name)
+	})
+
+	require.ElementsMatch([]string{"repeated", "new"}, followed)
+}
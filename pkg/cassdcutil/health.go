@@ -0,0 +1,131 @@
+package cassdcutil
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	cassdcapi "github.com/k8ssandra/cass-operator/apis/cassandra/v1beta1"
+	"github.com/k8ssandra/cass-operator/pkg/httphelper"
+	"github.com/k8ssandra/k8ssandra-client/pkg/mgmtapi"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// VerifyNodesUp returns an error listing the pods of the datacenter which are not running an Up and Normal Cassandra node
+func (c *CassManager) VerifyNodesUp(ctx context.Context, cassdc *cassdcapi.CassandraDatacenter) error {
+	podList, err := c.CassandraDatacenterPods(ctx, cassdc)
+	if err != nil {
+		return err
+	}
+
+	endpoints, err := c.endpointStates(ctx, cassdc)
+	if err != nil {
+		return fmt.Errorf("unable to fetch the Cassandra node states: %w", err)
+	}
+
+	problems := make([]string, 0)
+	if int32(len(podList.Items)) < cassdc.Spec.Size {
+		problems = append(problems, fmt.Sprintf("only %d of %d pods exist", len(podList.Items), cassdc.Spec.Size))
+	}
+
+	for i := range podList.Items {
+		pod := &podList.Items[i]
+		endpoint, found := findEndpointState(cassdc, pod, endpoints)
+		if !found {
+			problems = append(problems, fmt.Sprintf("%s is not part of the ring", pod.Name))
+			continue
+		}
+		if state := NodeStateString(endpoint); state != "UN" {
+			problems = append(problems, fmt.Sprintf("%s is %s", pod.Name, state))
+		}
+	}
+
+	if len(problems) > 0 {
+		sort.Strings(problems)
+		return fmt.Errorf("not all nodes are up and normal: %s", strings.Join(problems, ", "))
+	}
+
+	return nil
+}
+
+// VerifySchemaAgreement returns an error if the Cassandra nodes do not agree on the schema version
+func (c *CassManager) VerifySchemaAgreement(ctx context.Context, cassdc *cassdcapi.CassandraDatacenter) error {
+	var versions map[string][]string
+	err := c.CallPod(ctx, cassdc, PodSelector{}, func(mgmtClient *httphelper.NodeMgmtClient, pod *corev1.Pod) error {
+		var err error
+		versions, err = mgmtClient.CallSchemaVersionsEndpoint(pod)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("unable to fetch the schema versions: %w", err)
+	}
+
+	if len(versions) > 1 {
+		disagreement := make([]string, 0, len(versions))
+		for version, hosts := range versions {
+			disagreement = append(disagreement, fmt.Sprintf("%s: [%s]", version, strings.Join(hosts, ", ")))
+		}
+		sort.Strings(disagreement)
+		return fmt.Errorf("nodes do not agree on the schema version: %s", strings.Join(disagreement, ", "))
+	}
+
+	return nil
+}
+
+// PreflightError lists every problem found by a preflight check
+type PreflightError struct {
+	Problems []string
+}
+
+func (e *PreflightError) Error() string {
+	return fmt.Sprintf("preflight checks failed:\n  - %s", strings.Join(e.Problems, "\n  - "))
+}
+
+// QuorumSurvivesNodeLoss returns true if QUORUM can still be reached with replication factor rf when one replica
+// is down. cass-operator restarts one pod at a time, so a rolling restart never has more than one node down.
+func QuorumSurvivesNodeLoss(rf int) bool {
+	return rf-1 >= rf/2+1
+}
+
+// VerifyRestartSafety checks the datacenter can do a rolling restart without losing availability: every node is up
+// and normal, the nodes agree on the schema and every keyspace can serve QUORUM requests while the one node being
+// restarted is down. All the problems found are returned as a *PreflightError.
+func (c *CassManager) VerifyRestartSafety(ctx context.Context, cassdc *cassdcapi.CassandraDatacenter) error {
+	problems := make([]string, 0)
+
+	if err := c.VerifyNodesUp(ctx, cassdc); err != nil {
+		problems = append(problems, err.Error())
+	}
+
+	if err := c.VerifySchemaAgreement(ctx, cassdc); err != nil {
+		problems = append(problems, err.Error())
+	}
+
+	var factors map[string]int
+	err := c.CallPod(ctx, cassdc, PodSelector{}, func(mgmtClient *httphelper.NodeMgmtClient, pod *corev1.Pod) error {
+		var err error
+		factors, err = mgmtapi.ReplicationFactors(mgmtClient, pod, cassdc.Name)
+		return err
+	})
+	if err != nil {
+		problems = append(problems, fmt.Sprintf("unable to verify keyspace replication factors: %v", err))
+	}
+
+	keyspaces := make([]string, 0, len(factors))
+	for keyspace, rf := range factors {
+		if !QuorumSurvivesNodeLoss(rf) {
+			keyspaces = append(keyspaces, fmt.Sprintf("%s (RF %d)", keyspace, rf))
+		}
+	}
+	if len(keyspaces) > 0 {
+		sort.Strings(keyspaces)
+		problems = append(problems, fmt.Sprintf("QUORUM is not available while a node is restarting for keyspaces: %s", strings.Join(keyspaces, ", ")))
+	}
+
+	if len(problems) > 0 {
+		return &PreflightError{Problems: problems}
+	}
+
+	return nil
+}
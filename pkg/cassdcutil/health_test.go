@@ -0,0 +1,18 @@
+package cassdcutil
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuorumSurvivesNodeLoss(t *testing.T) {
+	require := require.New(t)
+
+	require.False(QuorumSurvivesNodeLoss(1))
+	require.False(QuorumSurvivesNodeLoss(2))
+	// The usual RF 3 with 3 racks keeps QUORUM while a single node restarts
+	require.True(QuorumSurvivesNodeLoss(3))
+	require.True(QuorumSurvivesNodeLoss(4))
+	require.True(QuorumSurvivesNodeLoss(5))
+}
@@ -0,0 +1,190 @@
+package cassdcutil
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	cassdcapi "github.com/k8ssandra/cass-operator/apis/cassandra/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
+)
+
+const (
+	// CassandraContainer runs the Cassandra node in the pods created by cass-operator
+	CassandraContainer = "cassandra"
+	// SystemLoggerContainer tails the Cassandra system.log in the pods created by cass-operator
+	SystemLoggerContainer = "server-system-logger"
+
+	// maxLogLineLength limits the length of a single log line, longer lines fail the stream of the container
+	maxLogLineLength = 1024 * 1024
+)
+
+// LogOptions select the logs read by StreamLogs
+type LogOptions struct {
+	// Rack limits the logs to the pods of the rack
+	Rack string
+	// Containers are the containers read from every pod, CassandraContainer if empty
+	Containers []string
+	// Follow keeps streaming new lines until the context is done
+	Follow bool
+	// Since limits the logs to the lines newer than the duration, zero reads all the lines
+	Since time.Duration
+	// Grep drops the lines not matching the expression
+	Grep *regexp.Regexp
+}
+
+// LogLine is a single line of a container's log
+type LogLine struct {
+	Pod       string
+	Container string
+	Time      time.Time
+	Text      string
+}
+
+// Source returns the pod and the container the line was read from, the container is left out for the Cassandra
+// container
+func (l LogLine) Source() string {
+	if l.Container == CassandraContainer {
+		return l.Pod
+	}
+	return fmt.Sprintf("%s/%s", l.Pod, l.Container)
+}
+
+// LogFunc receives the lines read by StreamLogs. It is never called concurrently.
+type LogFunc func(line LogLine)
+
+// LogStreamError lists the containers whose logs could not be read, keyed by LogLine.Source
+type LogStreamError struct {
+	Failures map[string]error
+}
+
+func (e *LogStreamError) Error() string {
+	sources := make([]string, 0, len(e.Failures))
+	for source := range e.Failures {
+		sources = append(sources, source)
+	}
+	sort.Strings(sources)
+
+	failures := make([]string, 0, len(sources))
+	for _, source := range sources {
+		failures = append(failures, fmt.Sprintf("%s: %v", source, e.Failures[source]))
+	}
+	return fmt.Sprintf("unable to read the logs of %d container(s): %s", len(failures), strings.Join(failures, ", "))
+}
+
+// ParseLogLine splits the timestamp the kubelet prefixes the lines with from the text. Lines without a valid timestamp
+// are returned as is with a zero time.
+func ParseLogLine(pod, container, raw string) LogLine {
+	line := LogLine{Pod: pod, Container: container, Text: raw}
+	timestamp, text, found := strings.Cut(raw, " ")
+	if !found {
+		return line
+	}
+	t, err := time.Parse(time.RFC3339Nano, timestamp)
+	if err != nil {
+		return line
+	}
+	line.Time = t
+	line.Text = text
+	return line
+}
+
+// SortLogLines orders the lines by their timestamps, keeping the order of each container's lines
+func SortLogLines(lines []LogLine) {
+	sort.SliceStable(lines, func(i, j int) bool {
+		return lines[i].Time.Before(lines[j].Time)
+	})
+}
+
+// StreamLogs reads the logs of the containers from every pod of the datacenter concurrently and calls handler for
+// every line, in the order they are received. With opts.Follow the call returns once the context is done. All the
+// streams are attempted and a *LogStreamError lists the ones which failed.
+func (c *CassManager) StreamLogs(ctx context.Context, pods corev1client.PodsGetter, cassdc *cassdcapi.CassandraDatacenter, opts LogOptions, handler LogFunc) error {
+	podList, err := c.CassandraDatacenterPods(ctx, cassdc)
+	if err != nil {
+		return err
+	}
+
+	containers := opts.Containers
+	if len(containers) == 0 {
+		containers = []string{CassandraContainer}
+	}
+
+	logOpts := &corev1.PodLogOptions{
+		Follow:     opts.Follow,
+		Timestamps: true,
+	}
+	if opts.Since > 0 {
+		sinceSeconds := int64(opts.Since.Round(time.Second).Seconds())
+		if sinceSeconds < 1 {
+			sinceSeconds = 1
+		}
+		logOpts.SinceSeconds = &sinceSeconds
+	}
+
+	var (
+		mu       sync.Mutex
+		wg       sync.WaitGroup
+		failures = make(map[string]error)
+	)
+
+	for i := range podList.Items {
+		pod := &podList.Items[i]
+		if opts.Rack != "" && pod.Labels[cassdcapi.RackLabel] != cassdcapi.CleanLabelValue(opts.Rack) {
+			continue
+		}
+
+		for _, container := range containers {
+			containerOpts := logOpts.DeepCopy()
+			containerOpts.Container = container
+			source := LogLine{Pod: pod.Name, Container: container}.Source()
+
+			wg.Add(1)
+			go func(podName string) {
+				defer wg.Done()
+
+				err := streamContainerLogs(ctx, pods, pod.Namespace, podName, containerOpts, func(line LogLine) {
+					if opts.Grep != nil && !opts.Grep.MatchString(line.Text) {
+						return
+					}
+					mu.Lock()
+					defer mu.Unlock()
+					handler(line)
+				})
+				if err != nil && ctx.Err() == nil {
+					mu.Lock()
+					failures[source] = err
+					mu.Unlock()
+				}
+			}(pod.Name)
+		}
+	}
+
+	wg.Wait()
+
+	if len(failures) > 0 {
+		return &LogStreamError{Failures: failures}
+	}
+	return nil
+}
+
+func streamContainerLogs(ctx context.Context, pods corev1client.PodsGetter, namespace, podName string, opts *corev1.PodLogOptions, handler LogFunc) error {
+	stream, err := pods.Pods(namespace).GetLogs(podName, opts).Stream(ctx)
+	if err != nil {
+		return err
+	}
+	defer stream.Close()
+
+	scanner := bufio.NewScanner(stream)
+	scanner.Buffer(make([]byte, 64*1024), maxLogLineLength)
+	for scanner.Scan() {
+		handler(ParseLogLine(podName, opts.Container, scanner.Text()))
+	}
+	return scanner.Err()
+}
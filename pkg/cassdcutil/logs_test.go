@@ -0,0 +1,40 @@
+package cassdcutil
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLogLine(t *testing.T) {
+	require := require.New(t)
+
+	line := ParseLogLine("pod-0", CassandraContainer, "2022-08-01T10:15:30.123456789Z INFO  [main] Starting")
+	require.Equal(time.Date(2022, 8, 1, 10, 15, 30, 123456789, time.UTC), line.Time)
+	require.Equal("INFO  [main] Starting", line.Text)
+	require.Equal("pod-0", line.Source())
+
+	line = ParseLogLine("pod-0", SystemLoggerContainer, "no timestamp here")
+	require.True(line.Time.IsZero())
+	require.Equal("no timestamp here", line.Text)
+	require.Equal("pod-0/server-system-logger", line.Source())
+}
+
+func TestSortLogLines(t *testing.T) {
+	require := require.New(t)
+
+	lines := []LogLine{
+		ParseLogLine("pod-0", CassandraContainer, "2022-08-01T10:15:32Z third"),
+		ParseLogLine("pod-0", CassandraContainer, "2022-08-01T10:15:32Z fourth"),
+		ParseLogLine("pod-1", CassandraContainer, "2022-08-01T10:15:30Z first"),
+		ParseLogLine("pod-1", CassandraContainer, "2022-08-01T10:15:31Z second"),
+	}
+	SortLogLines(lines)
+
+	texts := make([]string, 0, len(lines))
+	for _, line := range lines {
+		texts = append(texts, line.Text)
+	}
+	require.Equal([]string{"first", "second", "third", "fourth"}, texts)
+}
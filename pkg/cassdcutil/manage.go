@@ -2,26 +2,77 @@ package cassdcutil
 
 import (
 	"context"
+	"fmt"
+	"io"
 	"time"
 
 	cassdcapi "github.com/k8ssandra/cass-operator/apis/cassandra/v1beta1"
+	controlapi "github.com/k8ssandra/cass-operator/apis/control/v1alpha1"
+	"github.com/k8ssandra/k8ssandra-client/pkg/kubernetes"
 	"github.com/k8ssandra/k8ssandra-client/pkg/tasks"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
-	waitutil "k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/util/retry"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
 )
 
 type CassManager struct {
-	client client.Client
+	client    client.WithWatch
+	dryRun    kubernetes.DryRunStrategy
+	dryRunOut io.Writer
 }
 
-func NewManager(client client.Client) *CassManager {
+func NewManager(client client.WithWatch) *CassManager {
 	return &CassManager{
 		client: client,
+		dryRun: kubernetes.DryRunNone,
 	}
 }
 
+// DryRun makes the manager only simulate its changes and write them to out as a diff. Waiting for the changes
+// to take effect is skipped.
+func (c *CassManager) DryRun(strategy kubernetes.DryRunStrategy, out io.Writer) {
+	c.dryRun = strategy
+	c.dryRunOut = out
+}
+
+// waitEnabled returns false in dry-run mode as there is nothing to wait for
+func (c *CassManager) waitEnabled(wait bool) bool {
+	return wait && c.dryRun == kubernetes.DryRunNone
+}
+
+// reportDryRun writes the simulated change of the object, before is nil for created objects
+func (c *CassManager) reportDryRun(before, after client.Object) error {
+	gvk, err := apiutil.GVKForObject(after, c.client.Scheme())
+	if err != nil {
+		return err
+	}
+
+	after = after.DeepCopyObject().(client.Object)
+	after.GetObjectKind().SetGroupVersionKind(gvk)
+	if before != nil {
+		before = before.DeepCopyObject().(client.Object)
+		before.GetObjectKind().SetGroupVersionKind(gvk)
+	}
+
+	diff, err := kubernetes.Diff(before, after)
+	if err != nil {
+		return err
+	}
+
+	kind := gvk.Kind
+
+	if diff == "" {
+		_, err = fmt.Fprintf(c.dryRunOut, "%s %s/%s unchanged (dry run: %s)\n", kind, after.GetNamespace(), after.GetName(), c.dryRun)
+		return err
+	}
+
+	_, err = fmt.Fprintf(c.dryRunOut, "%s %s/%s (dry run: %s)\n%s", kind, after.GetNamespace(), after.GetName(), c.dryRun, diff)
+	return err
+}
+
 // CassandraDatacenter fetches the CassandraDatacenter by its name and namespace
 func (c *CassManager) CassandraDatacenter(ctx context.Context, name, namespace string) (*cassdcapi.CassandraDatacenter, error) {
 	cassdcKey := types.NamespacedName{Namespace: namespace, Name: name}
@@ -42,53 +93,91 @@ func (c *CassManager) CassandraDatacenterPods(ctx context.Context, cassdc *cassd
 	return podList, err
 }
 
-// ModifyStoppedState either stops or starts the cluster and does nothing if the state is already as requested
-func (c *CassManager) ModifyStoppedState(ctx context.Context, name, namespace string, stop, wait bool) error {
-	cassdc, err := c.CassandraDatacenter(ctx, name, namespace)
-	if err != nil {
-		return err
-	}
+// PatchDatacenter fetches the datacenter and lets modify change it. If modify returns true, the changes are sent as a
+// merge patch owned by kubernetes.FieldManager, so fields managed by others are not overwritten. The patch is
+// rejected if the datacenter was modified after it was fetched, in which case it is retried with a fresh copy.
+// In dry-run mode the change is only reported.
+func (c *CassManager) PatchDatacenter(ctx context.Context, name, namespace string, modify func(cassdc *cassdcapi.CassandraDatacenter) bool) (*cassdcapi.CassandraDatacenter, error) {
+	var patched *cassdcapi.CassandraDatacenter
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		cassdc, err := c.CassandraDatacenter(ctx, name, namespace)
+		if err != nil {
+			return err
+		}
 
-	cassdc = cassdc.DeepCopy()
+		original := cassdc.DeepCopy()
+		patch := client.MergeFromWithOptions(original, client.MergeFromWithOptimisticLock{})
+		changed := modify(cassdc)
+		patched = cassdc
+
+		patchOpts := []client.PatchOption{client.FieldOwner(kubernetes.FieldManager)}
+		switch {
+		case c.dryRun == kubernetes.DryRunClient || (!changed && c.dryRun != kubernetes.DryRunNone):
+			return c.reportDryRun(original, cassdc)
+		case !changed:
+			return nil
+		case c.dryRun == kubernetes.DryRunServer:
+			patchOpts = append(patchOpts, client.DryRunAll)
+		}
+
+		if err := c.client.Patch(ctx, cassdc, patch, patchOpts...); err != nil {
+			return err
+		}
 
-	cassdc.Spec.Stopped = stop
-	if err := c.client.Update(ctx, cassdc); err != nil {
-		// r.Log.Error(err, "failed to update the cassandradatacenter", "CassandraDatacenter", cassdcKey)
-		// return ctrl.Result{RequeueAfter: 10 * time.Second}, err
+		if c.dryRun == kubernetes.DryRunServer {
+			return c.reportDryRun(original, cassdc)
+		}
+		return nil
+	})
+
+	return patched, err
+}
+
+// ModifyStoppedState either stops or starts the cluster and does nothing if the state is already as requested.
+// If wait is set, the call blocks until the datacenter has reached the requested state or the context is done.
+func (c *CassManager) ModifyStoppedState(ctx context.Context, name, namespace string, stop, wait bool) error {
+	cassdc, err := c.PatchDatacenter(ctx, name, namespace, func(cassdc *cassdcapi.CassandraDatacenter) bool {
+		if cassdc.Spec.Stopped == stop {
+			return false
+		}
+		cassdc.Spec.Stopped = stop
+		return true
+	})
+	if err != nil {
 		return err
 	}
 
-	if wait {
+	if c.waitEnabled(wait) {
 		if stop {
-			err = waitutil.PollImmediate(10*time.Second, 10*time.Minute, func() (bool, error) {
-				return c.RefreshStatus(ctx, cassdc, cassdcapi.DatacenterStopped, corev1.ConditionTrue)
+			return c.WaitForConditions(ctx, cassdc, map[cassdcapi.DatacenterConditionType]corev1.ConditionStatus{
+				cassdcapi.DatacenterStopped: corev1.ConditionTrue,
+				cassdcapi.DatacenterReady:   corev1.ConditionFalse,
 			})
-			if err != nil {
-				return err
-			}
-
-			// And wait for it to finish..
-			return waitutil.PollImmediate(10*time.Second, 10*time.Minute, func() (bool, error) {
-				return c.RefreshStatus(ctx, cassdc, cassdcapi.DatacenterReady, corev1.ConditionFalse)
-			})
-		}
-
-		err = waitutil.PollImmediate(10*time.Second, 10*time.Minute, func() (bool, error) {
-			return c.RefreshStatus(ctx, cassdc, cassdcapi.DatacenterStopped, corev1.ConditionFalse)
-		})
-		if err != nil {
-			return err
 		}
 
-		// And wait for it to finish..
-		return waitutil.PollImmediate(10*time.Second, 10*time.Minute, func() (bool, error) {
-			return c.RefreshStatus(ctx, cassdc, cassdcapi.DatacenterReady, corev1.ConditionTrue)
+		return c.WaitForConditions(ctx, cassdc, map[cassdcapi.DatacenterConditionType]corev1.ConditionStatus{
+			cassdcapi.DatacenterStopped: corev1.ConditionFalse,
+			cassdcapi.DatacenterReady:   corev1.ConditionTrue,
 		})
 	}
 
 	return nil
 }
 
+// WaitForConditions watches the CassandraDatacenter until all the conditions have the wanted status
+func (c *CassManager) WaitForConditions(ctx context.Context, cassdc *cassdcapi.CassandraDatacenter, wanted map[cassdcapi.DatacenterConditionType]corev1.ConditionStatus) error {
+	cassdcKey := types.NamespacedName{Namespace: cassdc.Namespace, Name: cassdc.Name}
+	return kubernetes.WaitForObject(ctx, c.client, cassdcKey, &cassdcapi.CassandraDatacenter{}, &cassdcapi.CassandraDatacenterList{}, func(obj client.Object) (bool, error) {
+		dc := obj.(*cassdcapi.CassandraDatacenter)
+		for condition, status := range wanted {
+			if dc.Status.GetConditionStatus(condition) != status {
+				return false, nil
+			}
+		}
+		return true, nil
+	})
+}
+
 func (c *CassManager) RefreshStatus(ctx context.Context, cassdc *cassdcapi.CassandraDatacenter, status cassdcapi.DatacenterConditionType, wanted corev1.ConditionStatus) (bool, error) {
 	cassdc, err := c.CassandraDatacenter(ctx, cassdc.Name, cassdc.Namespace)
 	if err != nil {
@@ -98,22 +187,83 @@ func (c *CassManager) RefreshStatus(ctx context.Context, cassdc *cassdcapi.Cassa
 	return cassdc.Status.GetConditionStatus(status) == wanted, nil
 }
 
-func (c *CassManager) RestartDc(ctx context.Context, name, namespace, rack string, wait bool) error {
+// RestartDc creates a rolling restart task for the datacenter or only the given rack
+func (c *CassManager) RestartDc(ctx context.Context, name, namespace, rack string, opts *tasks.TaskOptions, wait bool) error {
+	_, err := c.RunTask(ctx, name, namespace, controlapi.CommandRestart, &controlapi.JobArguments{RackName: rack}, opts, wait)
+	return err
+}
+
+// RunTask creates a CassandraTask executing the command in the datacenter. If a task with the same command is
+// already running, a *tasks.TaskInProgressError is returned instead, unless opts allows concurrent tasks. opts may
+// be nil. If wait is set, the call blocks until the task has completed.
+func (c *CassManager) RunTask(ctx context.Context, name, namespace string, command controlapi.CassandraCommand, args *controlapi.JobArguments, opts *tasks.TaskOptions, wait bool) (*controlapi.CassandraTask, error) {
 	cassdc, err := c.CassandraDatacenter(ctx, name, namespace)
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	// Allowing concurrency is an explicit request to run next to the tasks already running
+	if opts == nil || opts.ConcurrencyPolicy != batchv1.AllowConcurrent {
+		active, err := c.ActiveTask(ctx, name, namespace, command)
+		if err != nil {
+			return nil, err
+		}
+
+		if active != nil {
+			return nil, &tasks.TaskInProgressError{Task: active.Name, Command: command, Datacenter: name}
+		}
+	}
+
+	taskOpts := &tasks.TaskOptions{}
+	if opts != nil {
+		*taskOpts = *opts
 	}
+	taskOpts.DryRun = c.dryRun
 
-	task, err := tasks.CreateRestartTask(ctx, c.client, cassdc, rack)
+	task, err := tasks.CreateTask(ctx, c.client, command, cassdc, args, taskOpts)
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	if c.dryRun != kubernetes.DryRunNone {
+		return task, c.reportDryRun(nil, task)
 	}
 
 	if wait {
-		err = tasks.WaitForCompletion(ctx, c.client, task)
-		if err != nil {
-			return err
+		if err := tasks.WaitForCompletion(ctx, c.client, task); err != nil {
+			return task, err
 		}
 	}
-	return nil
+	return task, nil
+}
+
+// ActiveTask returns the task running the command in the datacenter which has not completed yet, or nil if there
+// is none. Tasks waiting for their scheduled time or for cass-operator to pick them up are not running.
+func (c *CassManager) ActiveTask(ctx context.Context, name, namespace string, command controlapi.CassandraCommand) (*controlapi.CassandraTask, error) {
+	taskList := &controlapi.CassandraTaskList{}
+	if err := c.client.List(ctx, taskList, client.InNamespace(namespace)); err != nil {
+		return nil, err
+	}
+
+	return tasks.ActiveTask(taskList, name, command, time.Now()), nil
+}
+
+// PendingTasks returns the tasks with the command in the datacenter which have neither started nor reached their
+// scheduled time
+func (c *CassManager) PendingTasks(ctx context.Context, name, namespace string, command controlapi.CassandraCommand) ([]controlapi.CassandraTask, error) {
+	taskList := &controlapi.CassandraTaskList{}
+	if err := c.client.List(ctx, taskList, client.InNamespace(namespace)); err != nil {
+		return nil, err
+	}
+
+	return tasks.PendingTasks(taskList, name, command, time.Now()), nil
+}
+
+// WaitForTask blocks until the task has completed and returns a *tasks.TaskFailedError if it failed. In dry-run
+// mode it returns immediately.
+func (c *CassManager) WaitForTask(ctx context.Context, task *controlapi.CassandraTask) error {
+	if !c.waitEnabled(true) {
+		return nil
+	}
+	return tasks.WaitForCompletion(ctx, c.client, task)
 }
@@ -0,0 +1,62 @@
+package cassdcutil
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	cassdcapi "github.com/k8ssandra/cass-operator/apis/cassandra/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// SuperuserCredentials reads the username and password of the Cassandra superuser from the secret of the datacenter
+func (c *CassManager) SuperuserCredentials(ctx context.Context, cassdc *cassdcapi.CassandraDatacenter) (string, string, error) {
+	secret := &corev1.Secret{}
+	secretKey := cassdc.GetSuperuserSecretNamespacedName()
+	if err := c.client.Get(ctx, secretKey, secret); err != nil {
+		return "", "", err
+	}
+
+	username, password := string(secret.Data["username"]), string(secret.Data["password"])
+	if username == "" || password == "" {
+		return "", "", fmt.Errorf("superuser secret %s has no username or password", secretKey.Name)
+	}
+
+	return username, password, nil
+}
+
+// JMXAuthEnabled returns true if the Cassandra container of the pod requires authentication for JMX, which is the
+// case when JMX is opened for remote access
+func JMXAuthEnabled(pod *corev1.Pod) bool {
+	for _, container := range pod.Spec.Containers {
+		if container.Name != CassandraContainer {
+			continue
+		}
+		for _, env := range container.Env {
+			if env.Name == "LOCAL_JMX" {
+				return env.Value == "no"
+			}
+		}
+	}
+	return false
+}
+
+// NodetoolPasswordFile returns a nodetool password file with the credentials. The password is never passed on the
+// nodetool command line, which would make it visible in the process list and in the audit log of the exec request.
+func NodetoolPasswordFile(username, password string) (string, error) {
+	// nodetool splits the lines of the file at whitespace
+	if strings.ContainsAny(username+password, " \t\r\n") {
+		return "", fmt.Errorf("credentials containing whitespace can not be used with nodetool")
+	}
+	return fmt.Sprintf("%s %s\n", username, password), nil
+}
+
+// NodetoolCommand returns the nodetool command line with the arguments, username and the password file are only
+// passed if username is set
+func NodetoolCommand(args []string, username, passwordFile string) []string {
+	command := []string{"nodetool"}
+	if username != "" {
+		command = append(command, "--username", username, "--password-file", passwordFile)
+	}
+	return append(command, args...)
+}
@@ -0,0 +1,46 @@
+package cassdcutil
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestJMXAuthEnabled(t *testing.T) {
+	require := require.New(t)
+
+	pod := &corev1.Pod{
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{Name: SystemLoggerContainer, Env: []corev1.EnvVar{{Name: "LOCAL_JMX", Value: "no"}}},
+				{Name: CassandraContainer},
+			},
+		},
+	}
+	require.False(JMXAuthEnabled(pod))
+
+	pod.Spec.Containers[1].Env = []corev1.EnvVar{{Name: "LOCAL_JMX", Value: "yes"}}
+	require.False(JMXAuthEnabled(pod))
+
+	pod.Spec.Containers[1].Env = []corev1.EnvVar{{Name: "LOCAL_JMX", Value: "no"}}
+	require.True(JMXAuthEnabled(pod))
+}
+
+func TestNodetoolCommand(t *testing.T) {
+	require := require.New(t)
+
+	require.Equal([]string{"nodetool", "status"}, NodetoolCommand([]string{"status"}, "", ""))
+
+	passwordFile, err := NodetoolPasswordFile("admin", "secret")
+	require.NoError(err)
+	require.Equal("admin secret\n", passwordFile)
+
+	command := NodetoolCommand([]string{"status", "-r"}, "admin", "/tmp/.nodetool-password")
+	require.Equal([]string{"nodetool", "--username", "admin", "--password-file", "/tmp/.nodetool-password", "status", "-r"}, command)
+	require.NotContains(command, "secret")
+	require.NotContains(command, "--password")
+
+	_, err = NodetoolPasswordFile("admin", "two words")
+	require.Error(err)
+}
@@ -0,0 +1,74 @@
+package cassdcutil
+
+import (
+	"context"
+
+	controlapi "github.com/k8ssandra/cass-operator/apis/control/v1alpha1"
+	"github.com/k8ssandra/k8ssandra-client/pkg/tasks"
+)
+
+// JobState is the state of a single job in a pipeline
+type JobState string
+
+const (
+	JobPending   JobState = "Pending"
+	JobRunning   JobState = "Running"
+	JobCompleted JobState = "Completed"
+	JobFailed    JobState = "Failed"
+	JobSkipped   JobState = "Skipped"
+)
+
+// PipelineEvent reports the state change of a pipeline job
+type PipelineEvent struct {
+	Job   string
+	Task  string
+	State JobState
+	Err   error
+}
+
+// PipelineFunc receives the pipeline job state changes
+type PipelineFunc func(event PipelineEvent)
+
+// RunPipeline executes the jobs in order on the datacenter. cass-operator only accepts a single job per
+// CassandraTask, so every job is created as its own task once the previous one has completed. The pipeline stops
+// at the first failed job. The scheduled time of opts only applies to the first job.
+func (c *CassManager) RunPipeline(ctx context.Context, name, namespace string, jobs []controlapi.CassandraJob, opts *tasks.TaskOptions, progress PipelineFunc) error {
+	for i, job := range jobs {
+		progress(PipelineEvent{Job: tasks.JobName(i, job), State: JobPending})
+	}
+
+	for i, job := range jobs {
+		jobName := tasks.JobName(i, job)
+
+		jobOpts := &tasks.TaskOptions{}
+		if opts != nil {
+			*jobOpts = *opts
+		}
+		if i > 0 {
+			jobOpts.ScheduledTime = nil
+		}
+
+		args := job.Arguments
+		task, err := c.RunTask(ctx, name, namespace, job.Command, &args, jobOpts, false)
+		if err == nil {
+			progress(PipelineEvent{Job: jobName, Task: task.Name, State: JobRunning})
+			err = c.WaitForTask(ctx, task)
+		}
+
+		if err != nil {
+			event := PipelineEvent{Job: jobName, State: JobFailed, Err: err}
+			if task != nil {
+				event.Task = task.Name
+			}
+			progress(event)
+			for j := i + 1; j < len(jobs); j++ {
+				progress(PipelineEvent{Job: tasks.JobName(j, jobs[j]), State: JobSkipped})
+			}
+			return err
+		}
+
+		progress(PipelineEvent{Job: jobName, Task: task.Name, State: JobCompleted})
+	}
+
+	return nil
+}
@@ -0,0 +1,134 @@
+package cassdcutil
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	cassdcapi "github.com/k8ssandra/cass-operator/apis/cassandra/v1beta1"
+	"github.com/k8ssandra/k8ssandra-client/pkg/kubernetes"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/rest"
+)
+
+const (
+	// CQLPort is the native protocol port of the Cassandra container
+	CQLPort = cassdcapi.DefaultNativePort
+	// ManagementPort is the management API port of the Cassandra container
+	ManagementPort = 8080
+	// MetricsPort is the Prometheus metrics port of the Cassandra container
+	MetricsPort = 9103
+
+	// reconnectDelay is waited before the ports are forwarded again after the previous pod was lost
+	reconnectDelay = 2 * time.Second
+	// podCheckInterval is how often the readiness of the pod the ports are forwarded to is verified
+	podCheckInterval = 2 * time.Second
+)
+
+// ForwardPorts forwards the local ports to a ready pod of the datacenter until the context is done. The ports and
+// addresses use the format of kubectl port-forward. When the pod stops being ready or the connection to it is lost,
+// the ports are forwarded to another ready pod. The changes of the target pod are written to out. If the ports can
+// not be forwarded to the first pod, for example because a local port is already in use, the error is returned.
+func (c *CassManager) ForwardPorts(ctx context.Context, restConfig *rest.Config, cassdc *cassdcapi.CassandraDatacenter, addresses, ports []string, out io.Writer) error {
+	previous := ""
+	waiting := false
+	forwarded := false
+	for {
+		var noPodErr *NoPodAvailableError
+		pods, err := c.ReadyPods(ctx, cassdc, PodSelector{})
+		switch {
+		case ctx.Err() != nil:
+			return nil
+		case errors.As(err, &noPodErr):
+			if !waiting {
+				fmt.Fprintf(out, "No ready pods in datacenter %s, waiting for one\n", cassdc.Name)
+				waiting = true
+			}
+		case err != nil:
+			return err
+		default:
+			waiting = false
+			previous = NextPod(pods, previous)
+			listened, err := c.forwardToPod(ctx, restConfig, cassdc.Namespace, previous, addresses, ports, out)
+			forwarded = forwarded || listened
+			if err != nil {
+				if !forwarded {
+					return err
+				}
+				fmt.Fprintf(out, "Unable to forward to pod %s: %v\n", previous, err)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(reconnectDelay):
+		}
+	}
+}
+
+// NextPod returns the pod following previous by name, or the first pod if previous is not one of the pods. The
+// pods must be ordered by name.
+func NextPod(pods []corev1.Pod, previous string) string {
+	for i := range pods {
+		if pods[i].Name > previous {
+			return pods[i].Name
+		}
+	}
+	return pods[0].Name
+}
+
+// forwardToPod forwards the ports until the context is done, the connection is lost or the pod is no longer ready.
+// It returns true if the local ports were opened.
+func (c *CassManager) forwardToPod(ctx context.Context, restConfig *rest.Config, namespace, podName string, addresses, ports []string, out io.Writer) (bool, error) {
+	listened := false
+	stop := make(chan struct{})
+	ready := make(chan struct{})
+	done := make(chan error, 1)
+
+	go func() {
+		done <- kubernetes.ForwardPorts(restConfig, namespace, podName, addresses, ports, stop, ready, out)
+	}()
+
+	ticker := time.NewTicker(podCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case err := <-done:
+			if err == nil {
+				fmt.Fprintf(out, "Lost connection to pod %s\n", podName)
+			}
+			return listened, err
+		case <-ready:
+			fmt.Fprintf(out, "Forwarding %s to pod %s\n", strings.Join(ports, ", "), podName)
+			listened = true
+			ready = nil
+		case <-ctx.Done():
+			close(stop)
+			return listened, <-done
+		case <-ticker.C:
+			if !c.podStillReady(ctx, types.NamespacedName{Namespace: namespace, Name: podName}) {
+				fmt.Fprintf(out, "Pod %s is no longer ready\n", podName)
+				close(stop)
+				return listened, <-done
+			}
+		}
+	}
+}
+
+// podStillReady returns false if the pod is gone or would no longer be returned by ReadyPods. Other errors are
+// ignored, the connection to the pod may still work.
+func (c *CassManager) podStillReady(ctx context.Context, podKey types.NamespacedName) bool {
+	pod := &corev1.Pod{}
+	if err := c.client.Get(ctx, podKey, pod); err != nil {
+		return !apierrors.IsNotFound(err)
+	}
+	rank, reason := podRank(pod)
+	return reason == "" && rank == rankReadyStarted
+}
@@ -0,0 +1,27 @@
+package cassdcutil
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestNextPod(t *testing.T) {
+	require := require.New(t)
+
+	pods := []corev1.Pod{
+		{ObjectMeta: metav1.ObjectMeta{Name: "cluster1-dc1-r1-sts-0"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "cluster1-dc1-r2-sts-0"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "cluster1-dc1-r3-sts-0"}},
+	}
+
+	require.Equal("cluster1-dc1-r1-sts-0", NextPod(pods, ""))
+	require.Equal("cluster1-dc1-r2-sts-0", NextPod(pods, "cluster1-dc1-r1-sts-0"))
+	require.Equal("cluster1-dc1-r1-sts-0", NextPod(pods, "cluster1-dc1-r3-sts-0"))
+
+	// The lost pod is no longer ready
+	require.Equal("cluster1-dc1-r3-sts-0", NextPod(pods, "cluster1-dc1-r2-sts-1"))
+	require.Equal("cluster1-dc1-r1-sts-0", NextPod(pods[:1], "cluster1-dc1-r1-sts-0"))
+}
@@ -0,0 +1,143 @@
+package cassdcutil
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	cassdcapi "github.com/k8ssandra/cass-operator/apis/cassandra/v1beta1"
+	"github.com/k8ssandra/k8ssandra-client/pkg/kubernetes"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/client-go/tools/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// PodState is the simplified lifecycle state of a pod as shown to the user
+type PodState string
+
+const (
+	PodStarting    PodState = "Starting"
+	PodReady       PodState = "Ready"
+	PodRestarted   PodState = "Restarted"
+	PodTerminating PodState = "Terminating"
+	PodDeleted     PodState = "Deleted"
+
+	PodDraining     PodState = "Draining"
+	PodDrained      PodState = "Drained"
+	PodDrainFailed  PodState = "DrainFailed"
+	PodDrainSkipped PodState = "DrainSkipped"
+)
+
+// ProgressEvent is either a pod state change, a CassandraDatacenter condition transition or a Kubernetes event of
+// the datacenter
+type ProgressEvent struct {
+	Pod       string
+	PodState  PodState
+	Condition *cassdcapi.DatacenterCondition
+	Event     *corev1.Event
+	// Err is set when an operation on the pod failed
+	Err error
+}
+
+// ProgressFunc receives the events observed by WatchProgress
+type ProgressFunc func(event ProgressEvent)
+
+// WatchProgress follows the pods and the conditions of the CassandraDatacenter and calls progress for every change
+// until the context is done. The initial state of each pod and condition is reported as well. If rack is set, only
+// the pods of that rack are followed. The Kubernetes events of the datacenter are reported from the time of the call.
+func (c *CassManager) WatchProgress(ctx context.Context, cassdc *cassdcapi.CassandraDatacenter, rack string, progress ProgressFunc) {
+	started := time.Now()
+
+	podStates := make(map[string]PodState)
+	terminated := make(map[string]bool)
+
+	// All the informers call their handlers from their own goroutine
+	var mu sync.Mutex
+
+	updatePod := func(obj interface{}, deleted bool) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		pod, ok := obj.(*corev1.Pod)
+		if !ok {
+			if tombstone, isTombstone := obj.(cache.DeletedFinalStateUnknown); isTombstone {
+				pod, ok = tombstone.Obj.(*corev1.Pod)
+			}
+			if !ok {
+				return
+			}
+		}
+
+		state := podState(pod, deleted)
+		if state == PodTerminating || state == PodDeleted {
+			terminated[pod.Name] = true
+		} else if state == PodReady && terminated[pod.Name] {
+			state = PodRestarted
+		}
+
+		if podStates[pod.Name] == state {
+			return
+		}
+		podStates[pod.Name] = state
+		progress(ProgressEvent{Pod: pod.Name, PodState: state})
+	}
+
+	podLabels := map[string]string{cassdcapi.DatacenterLabel: cassdc.Name}
+	if rack != "" {
+		podLabels[cassdcapi.RackLabel] = cassdcapi.CleanLabelValue(rack)
+	}
+
+	podLw := kubernetes.NewListWatch(ctx, c.client, &corev1.PodList{}, client.InNamespace(cassdc.Namespace), client.MatchingLabels(podLabels))
+	_, podInformer := cache.NewInformer(podLw, &corev1.Pod{}, 0, cache.ResourceEventHandlerFuncs{
+		AddFunc:    func(obj interface{}) { updatePod(obj, false) },
+		UpdateFunc: func(_, obj interface{}) { updatePod(obj, false) },
+		DeleteFunc: func(obj interface{}) { updatePod(obj, true) },
+	})
+
+	conditions := make(map[cassdcapi.DatacenterConditionType]corev1.ConditionStatus)
+	updateConditions := func(obj interface{}) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		dc, ok := obj.(*cassdcapi.CassandraDatacenter)
+		if !ok {
+			return
+		}
+		for i := range dc.Status.Conditions {
+			condition := dc.Status.Conditions[i]
+			if conditions[condition.Type] == condition.Status {
+				continue
+			}
+			conditions[condition.Type] = condition.Status
+			progress(ProgressEvent{Condition: &condition})
+		}
+	}
+
+	dcLw := kubernetes.NewListWatch(ctx, c.client, &cassdcapi.CassandraDatacenterList{}, client.InNamespace(cassdc.Namespace), client.MatchingFieldsSelector{Selector: fields.OneTermEqualSelector("metadata.name", cassdc.Name)})
+	_, dcInformer := cache.NewInformer(dcLw, &cassdcapi.CassandraDatacenter{}, 0, cache.ResourceEventHandlerFuncs{
+		AddFunc:    updateConditions,
+		UpdateFunc: func(_, obj interface{}) { updateConditions(obj) },
+	})
+
+	go c.WatchEvents(ctx, cassdc, started, func(event *corev1.Event) {
+		mu.Lock()
+		defer mu.Unlock()
+		progress(ProgressEvent{Event: event})
+	})
+	go podInformer.Run(ctx.Done())
+	dcInformer.Run(ctx.Done())
+}
+
+func podState(pod *corev1.Pod, deleted bool) PodState {
+	switch {
+	case deleted:
+		return PodDeleted
+	case pod.DeletionTimestamp != nil:
+		return PodTerminating
+	case isPodReady(pod):
+		return PodReady
+	default:
+		return PodStarting
+	}
+}
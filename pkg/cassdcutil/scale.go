@@ -0,0 +1,92 @@
+package cassdcutil
+
+import (
+	"context"
+	"fmt"
+
+	cassdcapi "github.com/k8ssandra/cass-operator/apis/cassandra/v1beta1"
+	"github.com/k8ssandra/cass-operator/pkg/httphelper"
+	"github.com/k8ssandra/k8ssandra-client/pkg/kubernetes"
+	"github.com/k8ssandra/k8ssandra-client/pkg/mgmtapi"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ValidateScale verifies the datacenter can be scaled to the new size. The size must be a multiple of the rack count
+// and when scaling down, it can not go below the highest replication factor of any keyspace in the datacenter.
+func (c *CassManager) ValidateScale(ctx context.Context, cassdc *cassdcapi.CassandraDatacenter, size int32) error {
+	if size < 1 {
+		return fmt.Errorf("datacenter size must be at least 1")
+	}
+
+	racks := int32(len(cassdc.GetRacks()))
+	if size%racks != 0 {
+		return fmt.Errorf("datacenter size %d is not a multiple of the rack count %d", size, racks)
+	}
+
+	if size >= cassdc.Spec.Size {
+		return nil
+	}
+
+	var factors map[string]int
+	err := c.CallPod(ctx, cassdc, PodSelector{}, func(mgmtClient *httphelper.NodeMgmtClient, pod *corev1.Pod) error {
+		var err error
+		factors, err = mgmtapi.ReplicationFactors(mgmtClient, pod, cassdc.Name)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("unable to verify keyspace replication factors: %w", err)
+	}
+
+	for keyspace, rf := range factors {
+		if int32(rf) > size {
+			return fmt.Errorf("keyspace %s has replication factor %d in datacenter %s, unable to scale down to %d nodes", keyspace, rf, cassdc.Name, size)
+		}
+	}
+
+	return nil
+}
+
+// ScaleDatacenter sets the new size of the datacenter. If wait is set, the call blocks until cass-operator has
+// finished scaling and all the nodes are up.
+func (c *CassManager) ScaleDatacenter(ctx context.Context, name, namespace string, size int32, wait bool) error {
+	cassdc, err := c.PatchDatacenter(ctx, name, namespace, func(cassdc *cassdcapi.CassandraDatacenter) bool {
+		if cassdc.Spec.Size == size {
+			return false
+		}
+		cassdc.Spec.Size = size
+		return true
+	})
+	if err != nil {
+		return err
+	}
+
+	if c.waitEnabled(wait) {
+		return c.WaitForReconcile(ctx, cassdc)
+	}
+
+	return nil
+}
+
+// WaitForReconcile waits until cass-operator has processed the current generation of the datacenter and it is ready
+// with no scaling or updates in progress
+func (c *CassManager) WaitForReconcile(ctx context.Context, cassdc *cassdcapi.CassandraDatacenter) error {
+	cassdcKey := types.NamespacedName{Namespace: cassdc.Namespace, Name: cassdc.Name}
+	generation := cassdc.Generation
+
+	return kubernetes.WaitForObject(ctx, c.client, cassdcKey, &cassdcapi.CassandraDatacenter{}, &cassdcapi.CassandraDatacenterList{}, func(obj client.Object) (bool, error) {
+		dc := obj.(*cassdcapi.CassandraDatacenter)
+		if dc.Status.ObservedGeneration < generation || dc.Status.CassandraOperatorProgress != cassdcapi.ProgressReady {
+			return false, nil
+		}
+
+		for _, condition := range []cassdcapi.DatacenterConditionType{cassdcapi.DatacenterScalingUp, cassdcapi.DatacenterScalingDown, cassdcapi.DatacenterUpdating} {
+			if dc.Status.GetConditionStatus(condition) == corev1.ConditionTrue {
+				return false, nil
+			}
+		}
+
+		return dc.Status.GetConditionStatus(cassdcapi.DatacenterReady) == corev1.ConditionTrue, nil
+	})
+}
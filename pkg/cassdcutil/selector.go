@@ -0,0 +1,239 @@
+package cassdcutil
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	cassdcapi "github.com/k8ssandra/cass-operator/apis/cassandra/v1beta1"
+	"github.com/k8ssandra/cass-operator/pkg/httphelper"
+	"github.com/k8ssandra/k8ssandra-client/pkg/mgmtapi"
+	"github.com/spf13/pflag"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// nodeStateStarted is the value of the cassdcapi.CassNodeState label once the Cassandra node has been started
+const nodeStateStarted = "Started"
+
+// PodSelector limits the pods commands and management API calls are run on. The zero value allows every pod of
+// the datacenter.
+type PodSelector struct {
+	// Pod is the name of the only allowed pod
+	Pod string
+	// Rack limits the pods to the ones in the rack
+	Rack string
+}
+
+var errPodAndRackUsedTogether = fmt.Errorf("--pod and --rack can not be used together")
+
+// AddPodSelectorFlags adds the --pod and --rack flags setting the selector, the commands using them must call
+// Validate
+func AddPodSelectorFlags(flags *pflag.FlagSet, selector *PodSelector) {
+	flags.StringVar(&selector.Pod, "pod", "", "use this pod instead of the pods chosen automatically")
+	flags.StringVar(&selector.Rack, "rack", "", "choose the pods from this rack")
+}
+
+// Validate ensures the pod and the rack are not both set
+func (s PodSelector) Validate() error {
+	if s.Pod != "" && s.Rack != "" {
+		return errPodAndRackUsedTogether
+	}
+	return nil
+}
+
+func (s PodSelector) matches(pod *corev1.Pod) bool {
+	if s.Pod != "" && pod.Name != s.Pod {
+		return false
+	}
+	return s.Rack == "" || pod.Labels[cassdcapi.RackLabel] == cassdcapi.CleanLabelValue(s.Rack)
+}
+
+// NoPodAvailableError is returned when none of the selected pods can be used. Reasons explains for each selected pod
+// why it was not used.
+type NoPodAvailableError struct {
+	Datacenter string
+	Selector   PodSelector
+	Reasons    map[string]string
+}
+
+func (e *NoPodAvailableError) Error() string {
+	target := fmt.Sprintf("datacenter %s", e.Datacenter)
+	switch {
+	case e.Selector.Pod != "":
+		target = fmt.Sprintf("pod %s in %s", e.Selector.Pod, target)
+	case e.Selector.Rack != "":
+		target = fmt.Sprintf("rack %s of %s", e.Selector.Rack, target)
+	}
+
+	if len(e.Reasons) == 0 {
+		return fmt.Sprintf("no pods found for %s", target)
+	}
+
+	pods := make([]string, 0, len(e.Reasons))
+	for pod := range e.Reasons {
+		pods = append(pods, pod)
+	}
+	sort.Strings(pods)
+
+	reasons := make([]string, 0, len(pods))
+	for _, pod := range pods {
+		reasons = append(reasons, fmt.Sprintf("%s %s", pod, e.Reasons[pod]))
+	}
+	return fmt.Sprintf("no available pod for %s: %s", target, strings.Join(reasons, ", "))
+}
+
+const (
+	rankReadyStarted = iota
+	rankReady
+	rankStarted
+)
+
+// podRank returns the preference of the pod, lower is better, or the reason why the pod can not be used
+func podRank(pod *corev1.Pod) (int, string) {
+	ready := isPodReady(pod)
+	started := pod.Labels[cassdcapi.CassNodeState] == nodeStateStarted
+
+	switch {
+	case pod.DeletionTimestamp != nil:
+		return 0, "is terminating"
+	case pod.Status.Phase != corev1.PodRunning || pod.Status.PodIP == "":
+		return 0, "is not running"
+	case ready && started:
+		return rankReadyStarted, ""
+	case ready:
+		return rankReady, ""
+	case started:
+		return rankStarted, ""
+	default:
+		return 0, "is not ready and Cassandra has not started"
+	}
+}
+
+// RankPods returns the pods matching the selector, most preferred first: ready pods with a started Cassandra node,
+// then the other ready pods and last the running pods with a started node which are not ready. Pods of the same rank
+// are ordered by name. A *NoPodAvailableError is returned if none of the pods can be used.
+func RankPods(cassdc *cassdcapi.CassandraDatacenter, pods []corev1.Pod, selector PodSelector) ([]corev1.Pod, error) {
+	ranks := make(map[string]int)
+	reasons := make(map[string]string)
+	selected := make([]corev1.Pod, 0, len(pods))
+
+	for i := range pods {
+		pod := &pods[i]
+		if !selector.matches(pod) {
+			continue
+		}
+
+		rank, reason := podRank(pod)
+		if reason != "" {
+			reasons[pod.Name] = reason
+			continue
+		}
+		ranks[pod.Name] = rank
+		selected = append(selected, *pod)
+	}
+
+	if len(selected) == 0 {
+		return nil, &NoPodAvailableError{Datacenter: cassdc.Name, Selector: selector, Reasons: reasons}
+	}
+
+	sort.Slice(selected, func(i, j int) bool {
+		if ranks[selected[i].Name] != ranks[selected[j].Name] {
+			return ranks[selected[i].Name] < ranks[selected[j].Name]
+		}
+		return selected[i].Name < selected[j].Name
+	})
+
+	return selected, nil
+}
+
+// FilterReadyPods returns the pods matching the selector which RankPods prefers most, the ready pods with a started
+// Cassandra node, ordered by name. A *NoPodAvailableError is returned if there are none.
+func FilterReadyPods(cassdc *cassdcapi.CassandraDatacenter, pods []corev1.Pod, selector PodSelector) ([]corev1.Pod, error) {
+	reasons := make(map[string]string)
+	ready := make([]corev1.Pod, 0, len(pods))
+
+	for i := range pods {
+		pod := &pods[i]
+		if !selector.matches(pod) {
+			continue
+		}
+
+		rank, reason := podRank(pod)
+		switch {
+		case reason != "":
+			reasons[pod.Name] = reason
+		case rank == rankReady:
+			reasons[pod.Name] = "has not started Cassandra"
+		case rank == rankStarted:
+			reasons[pod.Name] = "is not ready"
+		default:
+			ready = append(ready, *pod)
+		}
+	}
+
+	if len(ready) == 0 {
+		return nil, &NoPodAvailableError{Datacenter: cassdc.Name, Selector: selector, Reasons: reasons}
+	}
+
+	sort.Slice(ready, func(i, j int) bool {
+		return ready[i].Name < ready[j].Name
+	})
+
+	return ready, nil
+}
+
+// SelectPods returns the pods of the datacenter matching the selector, most preferred first, see RankPods
+func (c *CassManager) SelectPods(ctx context.Context, cassdc *cassdcapi.CassandraDatacenter, selector PodSelector) ([]corev1.Pod, error) {
+	podList, err := c.CassandraDatacenterPods(ctx, cassdc)
+	if err != nil {
+		return nil, err
+	}
+
+	return RankPods(cassdc, podList.Items, selector)
+}
+
+// CallPod calls f with the pods matching the selector, most preferred first, until one of the calls succeeds. If
+// every call fails, the returned error describes the failure of each pod.
+func (c *CassManager) CallPod(ctx context.Context, cassdc *cassdcapi.CassandraDatacenter, selector PodSelector, f func(mgmtClient *httphelper.NodeMgmtClient, pod *corev1.Pod) error) error {
+	pods, err := c.SelectPods(ctx, cassdc, selector)
+	if err != nil {
+		return err
+	}
+
+	// Closes the port-forwards of the management client once the calls are done
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	mgmtClient, err := mgmtapi.NewManagementClient(ctx, c.client, cassdc)
+	if err != nil {
+		return err
+	}
+
+	failures := make([]string, 0, len(pods))
+	for i := range pods {
+		if err = f(&mgmtClient, &pods[i]); err == nil {
+			return nil
+		}
+		if ctx.Err() != nil {
+			return err
+		}
+		failures = append(failures, fmt.Sprintf("%s: %v", pods[i].Name, err))
+	}
+
+	if len(failures) == 1 {
+		return fmt.Errorf("pod %s: %w", pods[0].Name, err)
+	}
+	return fmt.Errorf("the management API call failed on all %d pods, %s", len(pods), strings.Join(failures, ", "))
+}
+
+// ReadyPods returns the ready pods of the datacenter matching the selector with a started Cassandra node, ordered by
+// name, see FilterReadyPods
+func (c *CassManager) ReadyPods(ctx context.Context, cassdc *cassdcapi.CassandraDatacenter, selector PodSelector) ([]corev1.Pod, error) {
+	podList, err := c.CassandraDatacenterPods(ctx, cassdc)
+	if err != nil {
+		return nil, err
+	}
+
+	return FilterReadyPods(cassdc, podList.Items, selector)
+}
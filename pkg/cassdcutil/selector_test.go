@@ -0,0 +1,149 @@
+package cassdcutil
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	cassdcapi "github.com/k8ssandra/cass-operator/apis/cassandra/v1beta1"
+	"github.com/k8ssandra/cass-operator/pkg/httphelper"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func selectorTestPod(name, rack string, running, ready, started bool) corev1.Pod {
+	pod := corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   name,
+			Labels: map[string]string{cassdcapi.RackLabel: rack},
+		},
+	}
+	if running {
+		pod.Status.Phase = corev1.PodRunning
+		pod.Status.PodIP = "10.0.0.1"
+	}
+	if ready {
+		pod.Status.Conditions = []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}}
+	}
+	if started {
+		pod.Labels[cassdcapi.CassNodeState] = nodeStateStarted
+	}
+	return pod
+}
+
+func podNames(pods []corev1.Pod) []string {
+	names := make([]string, 0, len(pods))
+	for _, pod := range pods {
+		names = append(names, pod.Name)
+	}
+	return names
+}
+
+func TestRankPods(t *testing.T) {
+	require := require.New(t)
+
+	cassdc := &cassdcapi.CassandraDatacenter{ObjectMeta: metav1.ObjectMeta{Name: "dc1"}}
+	pods := []corev1.Pod{
+		selectorTestPod("pod-0", "r1", false, false, false),
+		selectorTestPod("pod-1", "r1", true, false, true),
+		selectorTestPod("pod-2", "r2", true, true, false),
+		selectorTestPod("pod-3", "r2", true, true, true),
+		selectorTestPod("pod-4", "r3", true, false, false),
+	}
+
+	ranked, err := RankPods(cassdc, pods, PodSelector{})
+	require.NoError(err)
+	require.Equal([]string{"pod-3", "pod-2", "pod-1"}, podNames(ranked))
+
+	ranked, err = RankPods(cassdc, pods, PodSelector{Rack: "r1"})
+	require.NoError(err)
+	require.Equal([]string{"pod-1"}, podNames(ranked))
+
+	ranked, err = RankPods(cassdc, pods, PodSelector{Pod: "pod-2"})
+	require.NoError(err)
+	require.Equal([]string{"pod-2"}, podNames(ranked))
+
+	_, err = RankPods(cassdc, pods, PodSelector{Pod: "pod-0"})
+	require.EqualError(err, "no available pod for pod pod-0 in datacenter dc1: pod-0 is not running")
+
+	_, err = RankPods(cassdc, pods, PodSelector{Rack: "r3"})
+	require.EqualError(err, "no available pod for rack r3 of datacenter dc1: pod-4 is not ready and Cassandra has not started")
+
+	_, err = RankPods(cassdc, nil, PodSelector{})
+	var noPod *NoPodAvailableError
+	require.ErrorAs(err, &noPod)
+	require.EqualError(err, "no pods found for datacenter dc1")
+}
+
+func TestFilterReadyPods(t *testing.T) {
+	require := require.New(t)
+
+	cassdc := &cassdcapi.CassandraDatacenter{ObjectMeta: metav1.ObjectMeta{Name: "dc1"}}
+	terminating := selectorTestPod("pod-5", "r1", true, true, true)
+	terminating.DeletionTimestamp = &metav1.Time{}
+	pods := []corev1.Pod{
+		selectorTestPod("pod-4", "r2", true, true, true),
+		selectorTestPod("pod-3", "r1", true, true, true),
+		selectorTestPod("pod-2", "r1", true, true, false),
+		selectorTestPod("pod-1", "r1", true, false, true),
+		selectorTestPod("pod-0", "r2", false, false, false),
+		terminating,
+	}
+
+	ready, err := FilterReadyPods(cassdc, pods, PodSelector{})
+	require.NoError(err)
+	require.Equal([]string{"pod-3", "pod-4"}, podNames(ready))
+
+	_, err = FilterReadyPods(cassdc, pods[1:], PodSelector{Rack: "r1"})
+	require.NoError(err)
+
+	_, err = FilterReadyPods(cassdc, pods[2:], PodSelector{Rack: "r1"})
+	require.EqualError(err, "no available pod for rack r1 of datacenter dc1: pod-1 is not ready, pod-2 has not started Cassandra, pod-5 is terminating")
+}
+
+func TestPodSelectorValidate(t *testing.T) {
+	require := require.New(t)
+
+	require.NoError(PodSelector{}.Validate())
+	require.NoError(PodSelector{Pod: "pod-0"}.Validate())
+	require.NoError(PodSelector{Rack: "r1"}.Validate())
+	require.Error(PodSelector{Pod: "pod-0", Rack: "r1"}.Validate())
+}
+
+func TestCallPodFailover(t *testing.T) {
+	require := require.New(t)
+
+	scheme := runtime.NewScheme()
+	require.NoError(clientgoscheme.AddToScheme(scheme))
+	require.NoError(cassdcapi.AddToScheme(scheme))
+
+	cassdc := &cassdcapi.CassandraDatacenter{ObjectMeta: metav1.ObjectMeta{Name: "dc1", Namespace: "cass"}}
+	pods := []runtime.Object{}
+	for _, name := range []string{"pod-0", "pod-1"} {
+		pod := selectorTestPod(name, "r1", true, true, true)
+		pod.Namespace = "cass"
+		pod.Labels[cassdcapi.DatacenterLabel] = "dc1"
+		pods = append(pods, &pod)
+	}
+	cassManager := NewManager(fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(pods...).Build())
+
+	called := []string{}
+	err := cassManager.CallPod(context.Background(), cassdc, PodSelector{}, func(_ *httphelper.NodeMgmtClient, pod *corev1.Pod) error {
+		called = append(called, pod.Name)
+		if pod.Name == "pod-0" {
+			return fmt.Errorf("connection refused")
+		}
+		return nil
+	})
+	require.NoError(err)
+	require.Equal([]string{"pod-0", "pod-1"}, called)
+
+	err = cassManager.CallPod(context.Background(), cassdc, PodSelector{}, func(_ *httphelper.NodeMgmtClient, pod *corev1.Pod) error {
+		return fmt.Errorf("connection refused")
+	})
+	require.EqualError(err, "the management API call failed on all 2 pods, pod-0: connection refused, pod-1: connection refused")
+}
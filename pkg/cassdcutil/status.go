@@ -0,0 +1,177 @@
+package cassdcutil
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	cassdcapi "github.com/k8ssandra/cass-operator/apis/cassandra/v1beta1"
+	"github.com/k8ssandra/cass-operator/pkg/httphelper"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// DatacenterStatus is a summary of the CassandraDatacenter, its pods and the Cassandra nodes running in them
+type DatacenterStatus struct {
+	Name          string
+	Namespace     string
+	ClusterName   string
+	ServerType    string
+	ServerVersion string
+	Size          int32
+	Stopped       bool
+	Progress      cassdcapi.ProgressState
+	Conditions    []cassdcapi.DatacenterCondition
+	Racks         []RackStatus
+
+	// NodeStateErr is set if the Cassandra node states could not be fetched from the management API
+	NodeStateErr error
+}
+
+// RackStatus holds the pods of a single rack
+type RackStatus struct {
+	Name  string
+	Pods  []PodStatus
+	Ready int
+}
+
+// PodStatus combines the Kubernetes and Cassandra view of a single pod
+type PodStatus struct {
+	Name           string
+	IP             string
+	Phase          corev1.PodPhase
+	Ready          bool
+	NodeState      string
+	CassandraState string
+	ReleaseVersion string
+}
+
+// DatacenterStatus fetches the CassandraDatacenter, its pods and the state of the Cassandra nodes from the management API
+func (c *CassManager) DatacenterStatus(ctx context.Context, name, namespace string) (*DatacenterStatus, error) {
+	cassdc, err := c.CassandraDatacenter(ctx, name, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	podList, err := c.CassandraDatacenterPods(ctx, cassdc)
+	if err != nil {
+		return nil, err
+	}
+
+	status := &DatacenterStatus{
+		Name:          cassdc.Name,
+		Namespace:     cassdc.Namespace,
+		ClusterName:   cassdc.Spec.ClusterName,
+		ServerType:    cassdc.Spec.ServerType,
+		ServerVersion: cassdc.Spec.ServerVersion,
+		Size:          cassdc.Spec.Size,
+		Stopped:       cassdc.Spec.Stopped,
+		Progress:      cassdc.Status.CassandraOperatorProgress,
+		Conditions:    cassdc.Status.Conditions,
+	}
+
+	endpoints, err := c.endpointStates(ctx, cassdc)
+	if err != nil {
+		status.NodeStateErr = err
+	}
+
+	racks := make(map[string]*RackStatus, len(cassdc.GetRacks()))
+	for _, rack := range cassdc.GetRacks() {
+		status.Racks = append(status.Racks, RackStatus{Name: rack.Name})
+	}
+	for i := range status.Racks {
+		racks[cassdcapi.CleanLabelValue(status.Racks[i].Name)] = &status.Racks[i]
+	}
+
+	for _, pod := range podList.Items {
+		rack, found := racks[pod.Labels[cassdcapi.RackLabel]]
+		if !found {
+			// Pod belongs to a rack that has since been removed from the spec
+			continue
+		}
+
+		podStatus := PodStatus{
+			Name:      pod.Name,
+			IP:        pod.Status.PodIP,
+			Phase:     pod.Status.Phase,
+			Ready:     isPodReady(&pod),
+			NodeState: pod.Labels[cassdcapi.CassNodeState],
+		}
+
+		if endpoint, found := findEndpointState(cassdc, &pod, endpoints); found {
+			podStatus.CassandraState = NodeStateString(endpoint)
+			podStatus.ReleaseVersion = endpoint.ReleaseVersion
+		}
+
+		if podStatus.Ready {
+			rack.Ready++
+		}
+		rack.Pods = append(rack.Pods, podStatus)
+	}
+
+	for i := range status.Racks {
+		sort.Slice(status.Racks[i].Pods, func(a, b int) bool {
+			return status.Racks[i].Pods[a].Name < status.Racks[i].Pods[b].Name
+		})
+	}
+
+	return status, nil
+}
+
+// endpointStates fetches the gossip state of the ring from the first pod that responds
+func (c *CassManager) endpointStates(ctx context.Context, cassdc *cassdcapi.CassandraDatacenter) ([]httphelper.EndpointState, error) {
+	var endpoints httphelper.CassMetadataEndpoints
+	err := c.CallPod(ctx, cassdc, PodSelector{}, func(mgmtClient *httphelper.NodeMgmtClient, pod *corev1.Pod) error {
+		var err error
+		endpoints, err = mgmtClient.CallMetadataEndpointsEndpoint(pod)
+		return err
+	})
+	return endpoints.Entity, err
+}
+
+// findEndpointState matches the pod to its gossip state using the HostID, or the pod IP if the HostID is not known yet
+func findEndpointState(cassdc *cassdcapi.CassandraDatacenter, pod *corev1.Pod, endpoints []httphelper.EndpointState) (httphelper.EndpointState, bool) {
+	hostID := cassdc.Status.NodeStatuses[pod.Name].HostID
+	for _, endpoint := range endpoints {
+		if hostID != "" && endpoint.HostID == hostID {
+			return endpoint, true
+		}
+		if pod.Status.PodIP != "" && endpoint.GetRpcAddress() == pod.Status.PodIP {
+			return endpoint, true
+		}
+	}
+	return httphelper.EndpointState{}, false
+}
+
+// NodeStateString returns the nodetool status style two letter state of the node, such as UN or DN
+func NodeStateString(endpoint httphelper.EndpointState) string {
+	var sb strings.Builder
+	if endpoint.IsAlive == "true" {
+		sb.WriteRune('U')
+	} else {
+		sb.WriteRune('D')
+	}
+
+	switch {
+	case endpoint.HasStatus(httphelper.StatusNormal):
+		sb.WriteRune('N')
+	case endpoint.HasStatus("BOOT"):
+		sb.WriteRune('J')
+	case endpoint.HasStatus(httphelper.StatusLeaving), endpoint.HasStatus(httphelper.StatusLeft):
+		sb.WriteRune('L')
+	case endpoint.HasStatus(httphelper.StatusMoving):
+		sb.WriteRune('M')
+	default:
+		sb.WriteRune('?')
+	}
+
+	return sb.String()
+}
+
+func isPodReady(pod *corev1.Pod) bool {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
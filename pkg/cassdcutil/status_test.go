@@ -0,0 +1,18 @@
+package cassdcutil
+
+import (
+	"testing"
+
+	"github.com/k8ssandra/cass-operator/pkg/httphelper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNodeStateString(t *testing.T) {
+	require := require.New(t)
+
+	require.Equal("UN", NodeStateString(httphelper.EndpointState{IsAlive: "true", Status: "NORMAL,-9223372036854775808"}))
+	require.Equal("DN", NodeStateString(httphelper.EndpointState{IsAlive: "false", Status: "NORMAL,-9223372036854775808"}))
+	require.Equal("UJ", NodeStateString(httphelper.EndpointState{IsAlive: "true", StatusWithPort: "BOOT,-9223372036854775808"}))
+	require.Equal("UL", NodeStateString(httphelper.EndpointState{IsAlive: "true", Status: "LEAVING,-9223372036854775808"}))
+	require.Equal("D?", NodeStateString(httphelper.EndpointState{}))
+}
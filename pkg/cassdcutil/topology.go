@@ -0,0 +1,160 @@
+package cassdcutil
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	cassdcapi "github.com/k8ssandra/cass-operator/apis/cassandra/v1beta1"
+	"github.com/k8ssandra/k8ssandra-client/pkg/kubernetes"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Topology maps the Cassandra nodes of a datacenter to the Kubernetes nodes and zones they run on
+type Topology struct {
+	Name      string
+	Namespace string
+	Racks     []RackTopology
+
+	// Warnings describe placements which weaken the fault tolerance given by the racks
+	Warnings []string
+	// NodeErr is set if the Kubernetes nodes could not be listed, the zones are then not known
+	NodeErr error
+	// NodeStateErr is set if the Cassandra node states could not be fetched from the management API
+	NodeStateErr error
+}
+
+// RackTopology holds the placement of the pods of a single rack
+type RackTopology struct {
+	Name string
+	Pods []PodPlacement
+}
+
+// PodPlacement is the Kubernetes node and zone a pod runs on and the ring state of its Cassandra node
+type PodPlacement struct {
+	Name      string
+	Node      string
+	Zone      string
+	HostIP    string
+	RingState string
+}
+
+// DatacenterTopology fetches the pods of the datacenter, the Kubernetes nodes they run on and the state of the
+// Cassandra nodes in the ring
+func (c *CassManager) DatacenterTopology(ctx context.Context, name, namespace string) (*Topology, error) {
+	cassdc, err := c.CassandraDatacenter(ctx, name, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	podList, err := c.CassandraDatacenterPods(ctx, cassdc)
+	if err != nil {
+		return nil, err
+	}
+
+	topology := &Topology{
+		Name:      cassdc.Name,
+		Namespace: cassdc.Namespace,
+	}
+
+	// Listing the nodes requires cluster wide permissions, the pods still know their node without them
+	nodeNames, zones := map[string]string{}, map[string]string{}
+	nodes := &corev1.NodeList{}
+	if err := c.client.List(ctx, nodes); err != nil {
+		topology.NodeErr = err
+	} else {
+		nodeNames = kubernetes.NodeIPAddresses(nodes)
+		zones = kubernetes.NodeZones(nodes)
+	}
+
+	endpoints, err := c.endpointStates(ctx, cassdc)
+	if err != nil {
+		topology.NodeStateErr = err
+	}
+
+	racks := make(map[string]*RackTopology, len(cassdc.GetRacks()))
+	for _, rack := range cassdc.GetRacks() {
+		topology.Racks = append(topology.Racks, RackTopology{Name: rack.Name})
+	}
+	for i := range topology.Racks {
+		racks[cassdcapi.CleanLabelValue(topology.Racks[i].Name)] = &topology.Racks[i]
+	}
+
+	for i := range podList.Items {
+		pod := &podList.Items[i]
+		rack, found := racks[pod.Labels[cassdcapi.RackLabel]]
+		if !found {
+			// Pod belongs to a rack that has since been removed from the spec
+			continue
+		}
+
+		node := nodeNames[pod.Status.HostIP]
+		if node == "" {
+			node = pod.Spec.NodeName
+		}
+
+		placement := PodPlacement{
+			Name:   pod.Name,
+			Node:   node,
+			Zone:   zones[node],
+			HostIP: pod.Status.HostIP,
+		}
+		if endpoint, found := findEndpointState(cassdc, pod, endpoints); found {
+			placement.RingState = NodeStateString(endpoint)
+		}
+
+		rack.Pods = append(rack.Pods, placement)
+	}
+
+	for i := range topology.Racks {
+		sort.Slice(topology.Racks[i].Pods, func(a, b int) bool {
+			return topology.Racks[i].Pods[a].Name < topology.Racks[i].Pods[b].Name
+		})
+	}
+
+	topology.Warnings = TopologyWarnings(topology.Racks)
+
+	return topology, nil
+}
+
+// TopologyWarnings returns a warning for each Kubernetes node running several pods of the same rack and for each
+// rack spread over several zones
+func TopologyWarnings(racks []RackTopology) []string {
+	warnings := make([]string, 0)
+
+	for _, rack := range racks {
+		nodePods := make(map[string][]string)
+		zones := make(map[string]bool)
+		for _, pod := range rack.Pods {
+			if pod.Node != "" {
+				nodePods[pod.Node] = append(nodePods[pod.Node], pod.Name)
+			}
+			if pod.Zone != "" {
+				zones[pod.Zone] = true
+			}
+		}
+
+		nodes := make([]string, 0, len(nodePods))
+		for node, pods := range nodePods {
+			if len(pods) > 1 {
+				nodes = append(nodes, node)
+			}
+		}
+		sort.Strings(nodes)
+		for _, node := range nodes {
+			warnings = append(warnings, fmt.Sprintf("rack %s has pods %s on the same Kubernetes node %s", rack.Name, strings.Join(nodePods[node], ", "), node))
+		}
+
+		if len(zones) > 1 {
+			zoneNames := make([]string, 0, len(zones))
+			for zone := range zones {
+				zoneNames = append(zoneNames, zone)
+			}
+			sort.Strings(zoneNames)
+			warnings = append(warnings, fmt.Sprintf("rack %s spans several zones: %s", rack.Name, strings.Join(zoneNames, ", ")))
+		}
+	}
+
+	return warnings
+}
@@ -0,0 +1,43 @@
+package cassdcutil
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTopologyWarnings(t *testing.T) {
+	require := require.New(t)
+
+	racks := []RackTopology{
+		{
+			Name: "r1",
+			Pods: []PodPlacement{
+				{Name: "testcluster-dc1-r1-sts-0", Node: "node-a", Zone: "zone-a"},
+				{Name: "testcluster-dc1-r1-sts-1", Node: "node-b", Zone: "zone-a"},
+			},
+		},
+		{
+			Name: "r2",
+			Pods: []PodPlacement{
+				{Name: "testcluster-dc1-r2-sts-0", Node: "node-c", Zone: "zone-b"},
+				{Name: "testcluster-dc1-r2-sts-1", Node: "node-c", Zone: "zone-b"},
+			},
+		},
+		{
+			Name: "r3",
+			Pods: []PodPlacement{
+				{Name: "testcluster-dc1-r3-sts-0", Node: "node-d", Zone: "zone-c"},
+				{Name: "testcluster-dc1-r3-sts-1", Node: "node-e", Zone: "zone-a"},
+				{Name: "testcluster-dc1-r3-sts-2"},
+			},
+		},
+	}
+
+	require.Equal([]string{
+		"rack r2 has pods testcluster-dc1-r2-sts-0, testcluster-dc1-r2-sts-1 on the same Kubernetes node node-c",
+		"rack r3 spans several zones: zone-a, zone-c",
+	}, TopologyWarnings(racks))
+
+	require.Empty(TopologyWarnings(racks[:1]))
+}
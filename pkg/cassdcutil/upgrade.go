@@ -0,0 +1,114 @@
+package cassdcutil
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	cassdcapi "github.com/k8ssandra/cass-operator/apis/cassandra/v1beta1"
+	controlapi "github.com/k8ssandra/cass-operator/apis/control/v1alpha1"
+	"github.com/k8ssandra/cass-operator/pkg/images"
+)
+
+// ValidateVersionUpgrade verifies the server version can be changed from the current version to the target version.
+// Downgrades and skipping a major version are not allowed.
+func ValidateVersionUpgrade(serverType, current, target string) error {
+	switch serverType {
+	case "dse":
+		if !images.IsDseVersionSupported(target) {
+			return fmt.Errorf("DSE version %s is not supported", target)
+		}
+	default:
+		if !images.IsOssVersionSupported(target) {
+			return fmt.Errorf("Cassandra version %s is not supported", target)
+		}
+	}
+
+	currentVersion, err := parseVersion(current)
+	if err != nil {
+		return err
+	}
+
+	targetVersion, err := parseVersion(target)
+	if err != nil {
+		return err
+	}
+
+	for i := range currentVersion {
+		if targetVersion[i] > currentVersion[i] {
+			break
+		}
+		if targetVersion[i] < currentVersion[i] {
+			return fmt.Errorf("downgrading from %s to %s is not supported", current, target)
+		}
+	}
+
+	if targetVersion[0]-currentVersion[0] > 1 {
+		return fmt.Errorf("upgrading from %s to %s skips a major version, upgrade to %d.x first", current, target, currentVersion[0]+1)
+	}
+
+	return nil
+}
+
+// parseVersion parses major.minor.patch version into its components
+func parseVersion(version string) ([3]int, error) {
+	var parsed [3]int
+	parts := strings.Split(version, ".")
+	if len(parts) != len(parsed) {
+		return parsed, fmt.Errorf("version %s is not in major.minor.patch format", version)
+	}
+
+	for i, part := range parts {
+		v, err := strconv.Atoi(part)
+		if err != nil {
+			return parsed, fmt.Errorf("version %s is not in major.minor.patch format", version)
+		}
+		parsed[i] = v
+	}
+
+	return parsed, nil
+}
+
+// ValidateUpgrade verifies the version change is supported and the datacenter is healthy enough to be upgraded
+func (c *CassManager) ValidateUpgrade(ctx context.Context, cassdc *cassdcapi.CassandraDatacenter, version string) error {
+	if err := ValidateVersionUpgrade(cassdc.Spec.ServerType, cassdc.Spec.ServerVersion, version); err != nil {
+		return err
+	}
+
+	if err := c.VerifyNodesUp(ctx, cassdc); err != nil {
+		return err
+	}
+
+	return c.VerifySchemaAgreement(ctx, cassdc)
+}
+
+// UpgradeServerVersion sets the new server version and optionally the image of the datacenter, which causes
+// cass-operator to do a rolling update of the pods. If wait is set, the call blocks until the update has finished.
+func (c *CassManager) UpgradeServerVersion(ctx context.Context, name, namespace, version, image string, wait bool) error {
+	cassdc, err := c.PatchDatacenter(ctx, name, namespace, func(cassdc *cassdcapi.CassandraDatacenter) bool {
+		if cassdc.Spec.ServerVersion == version && (image == "" || cassdc.Spec.ServerImage == image) {
+			return false
+		}
+		cassdc.Spec.ServerVersion = version
+		if image != "" {
+			cassdc.Spec.ServerImage = image
+		}
+		return true
+	})
+	if err != nil {
+		return err
+	}
+
+	if c.waitEnabled(wait) {
+		return c.WaitForReconcile(ctx, cassdc)
+	}
+
+	return nil
+}
+
+// UpgradeSSTables creates a task that rewrites the SSTables of every node to the current format
+func (c *CassManager) UpgradeSSTables(ctx context.Context, name, namespace string, wait bool) error {
+	_, err := c.RunTask(ctx, name, namespace, controlapi.CommandUpgradeSSTables, nil, nil, wait)
+	return err
+}
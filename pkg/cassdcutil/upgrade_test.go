@@ -0,0 +1,24 @@
+package cassdcutil
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateVersionUpgrade(t *testing.T) {
+	require := require.New(t)
+
+	require.NoError(ValidateVersionUpgrade("cassandra", "4.0.1", "4.0.7"))
+	require.NoError(ValidateVersionUpgrade("cassandra", "4.0.7", "4.1.0"))
+	require.NoError(ValidateVersionUpgrade("cassandra", "3.11.14", "4.0.7"))
+	require.NoError(ValidateVersionUpgrade("cassandra", "4.0.7", "4.0.7"))
+	require.NoError(ValidateVersionUpgrade("dse", "6.8.25", "6.8.28"))
+
+	require.Error(ValidateVersionUpgrade("cassandra", "4.0.7", "4.0.1"))
+	require.Error(ValidateVersionUpgrade("cassandra", "4.1.0", "3.11.14"))
+	require.Error(ValidateVersionUpgrade("cassandra", "4.0.7", "4.1"))
+	require.Error(ValidateVersionUpgrade("cassandra", "4.0.7", "5.0.0-beta1"))
+	require.Error(ValidateVersionUpgrade("cassandra", "3.11.14", "3.0.27"))
+	require.Error(ValidateVersionUpgrade("dse", "6.8.25", "4.0.7"))
+}
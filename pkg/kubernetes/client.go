@@ -4,28 +4,48 @@ import (
 	"context"
 
 	cassdcapi "github.com/k8ssandra/cass-operator/apis/cassandra/v1beta1"
+	controlapi "github.com/k8ssandra/cass-operator/apis/control/v1alpha1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
 	"k8s.io/client-go/rest"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+// FieldManager is the field manager name of the changes made by this client, shown in the managedFields of the objects
+const FieldManager = "k8ssandra-client"
+
 // NamespacedClient encapsulates namespacedClient with public namespace and restConfig
 type NamespacedClient struct {
 	client.Client
+	watcher   client.WithWatch
 	config    *rest.Config
 	Namespace string
 }
 
+// Config returns the REST configuration the client was created with
+func (n NamespacedClient) Config() *rest.Config {
+	return n.config
+}
+
+// Watch implements client.WithWatch, the watch is limited to the client's namespace
+func (n NamespacedClient) Watch(ctx context.Context, obj client.ObjectList, opts ...client.ListOption) (watch.Interface, error) {
+	return n.watcher.Watch(ctx, obj, append(opts, client.InNamespace(n.Namespace))...)
+}
+
 // GetClient returns a controller-runtime client with cass-operator API defined
-func GetClient(restConfig *rest.Config) (client.Client, error) {
-	c, err := client.New(restConfig, client.Options{})
+func GetClient(restConfig *rest.Config) (client.WithWatch, error) {
+	c, err := client.NewWithWatch(restConfig, client.Options{})
 	if err != nil {
 		return nil, err
 	}
 
-	err = cassdcapi.AddToScheme(c.Scheme())
+	if err := cassdcapi.AddToScheme(c.Scheme()); err != nil {
+		return nil, err
+	}
+
+	err = controlapi.AddToScheme(c.Scheme())
 
 	return c, err
 }
@@ -36,12 +56,12 @@ func GetClientInNamespace(restConfig *rest.Config, namespace string) (Namespaced
 		return NamespacedClient{}, err
 	}
 
-	c = client.NewNamespacedClient(c, namespace)
 	return NamespacedClient{
-		config: restConfig,
-		Client: c,
+		config:    restConfig,
+		Client:    client.NewNamespacedClient(c, namespace),
+		watcher:   c,
+		Namespace: namespace,
 	}, nil
-	// return c, nil
 }
 
 func CreateNamespaceIfNotExists(client client.Client, namespace string) error {
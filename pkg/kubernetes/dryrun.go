@@ -0,0 +1,77 @@
+package kubernetes
+
+import (
+	"fmt"
+
+	"github.com/pmezard/go-difflib/difflib"
+	"github.com/spf13/pflag"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+)
+
+// DryRunStrategy defines if and where changes are only simulated
+type DryRunStrategy string
+
+const (
+	// DryRunNone persists the changes
+	DryRunNone DryRunStrategy = "none"
+	// DryRunClient computes the changes locally without sending them
+	DryRunClient DryRunStrategy = "client"
+	// DryRunServer sends the changes with dry-run set, so they are defaulted and validated, but not persisted
+	DryRunServer DryRunStrategy = "server"
+)
+
+// ParseDryRun parses the value of a --dry-run flag
+func ParseDryRun(value string) (DryRunStrategy, error) {
+	switch DryRunStrategy(value) {
+	case "", DryRunNone:
+		return DryRunNone, nil
+	case DryRunClient, DryRunServer:
+		return DryRunStrategy(value), nil
+	default:
+		return DryRunNone, fmt.Errorf("invalid dry-run value %s, must be none, client or server", value)
+	}
+}
+
+// Diff returns a unified diff of the YAML representations of the objects. before may be nil for a new object.
+// Managed fields are left out as they only add noise.
+func Diff(before, after client.Object) (string, error) {
+	var beforeLines []string
+	if before != nil {
+		beforeYaml, err := diffYaml(before)
+		if err != nil {
+			return "", err
+		}
+		beforeLines = difflib.SplitLines(beforeYaml)
+	}
+
+	afterYaml, err := diffYaml(after)
+	if err != nil {
+		return "", err
+	}
+
+	return difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        beforeLines,
+		B:        difflib.SplitLines(afterYaml),
+		FromFile: "current",
+		ToFile:   "dry-run",
+		Context:  3,
+	})
+}
+
+func diffYaml(obj client.Object) (string, error) {
+	obj = obj.DeepCopyObject().(client.Object)
+	obj.SetManagedFields(nil)
+
+	out, err := yaml.Marshal(obj)
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
+
+// AddDryRunFlag registers the --dry-run flag, given without a value it means client side dry-run
+func AddDryRunFlag(flags *pflag.FlagSet, value *string) {
+	flags.StringVar(value, "dry-run", string(DryRunNone), `must be "none", "client" or "server". With "client" the changes are only shown, with "server" they are also validated by the API server without persisting them`)
+	flags.Lookup("dry-run").NoOptDefVal = string(DryRunClient)
+}
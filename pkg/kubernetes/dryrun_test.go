@@ -0,0 +1,50 @@
+package kubernetes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestParseDryRun(t *testing.T) {
+	require := require.New(t)
+
+	for value, expected := range map[string]DryRunStrategy{"": DryRunNone, "none": DryRunNone, "client": DryRunClient, "server": DryRunServer} {
+		strategy, err := ParseDryRun(value)
+		require.NoError(err)
+		require.Equal(expected, strategy)
+	}
+
+	_, err := ParseDryRun("true")
+	require.Error(err)
+}
+
+func TestDiff(t *testing.T) {
+	require := require.New(t)
+
+	before := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:          "config",
+			ManagedFields: []metav1.ManagedFieldsEntry{{Manager: "kubectl"}},
+		},
+		Data: map[string]string{"size": "3"},
+	}
+	after := before.DeepCopy()
+	after.Data["size"] = "6"
+
+	diff, err := Diff(before, after)
+	require.NoError(err)
+	require.Contains(diff, "-  size: \"3\"")
+	require.Contains(diff, "+  size: \"6\"")
+	require.NotContains(diff, "kubectl")
+
+	diff, err = Diff(before, before)
+	require.NoError(err)
+	require.Empty(diff)
+
+	diff, err = Diff(nil, after)
+	require.NoError(err)
+	require.Contains(diff, "+  name: config")
+}
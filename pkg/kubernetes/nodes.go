@@ -15,6 +15,11 @@ func GetAllKubernetesNodeIPAddresses(ctx context.Context, cli client.Client) (ma
 		return nil, err
 	}
 
+	return NodeIPAddresses(nodes), nil
+}
+
+// NodeIPAddresses returns a mapping of IPAddress -> node_name for the internal IP addresses of the nodes
+func NodeIPAddresses(nodes *corev1.NodeList) map[string]string {
 	ipAddresses := make(map[string]string, len(nodes.Items))
 
 	for _, node := range nodes.Items {
@@ -25,5 +30,20 @@ func GetAllKubernetesNodeIPAddresses(ctx context.Context, cli client.Client) (ma
 		}
 	}
 
-	return ipAddresses, nil
+	return ipAddresses
+}
+
+// NodeZones returns a mapping of node_name -> zone. Nodes without a zone label are not included.
+func NodeZones(nodes *corev1.NodeList) map[string]string {
+	zones := make(map[string]string, len(nodes.Items))
+
+	for _, node := range nodes.Items {
+		if zone, found := node.Labels[corev1.LabelTopologyZone]; found {
+			zones[node.Name] = zone
+		} else if zone, found := node.Labels[corev1.LabelFailureDomainBetaZone]; found {
+			zones[node.Name] = zone
+		}
+	}
+
+	return zones
 }
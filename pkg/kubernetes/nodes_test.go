@@ -0,0 +1,33 @@
+package kubernetes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestNodeMappings(t *testing.T) {
+	require := require.New(t)
+
+	nodes := &corev1.NodeList{Items: []corev1.Node{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "node-a", Labels: map[string]string{corev1.LabelTopologyZone: "zone-a"}},
+			Status: corev1.NodeStatus{Addresses: []corev1.NodeAddress{
+				{Type: corev1.NodeExternalIP, Address: "203.0.113.1"},
+				{Type: corev1.NodeInternalIP, Address: "10.0.0.1"},
+			}},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "node-b", Labels: map[string]string{corev1.LabelFailureDomainBetaZone: "zone-b"}},
+			Status:     corev1.NodeStatus{Addresses: []corev1.NodeAddress{{Type: corev1.NodeInternalIP, Address: "10.0.0.2"}}},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "node-c"},
+		},
+	}}
+
+	require.Equal(map[string]string{"10.0.0.1": "node-a", "10.0.0.2": "node-b"}, NodeIPAddresses(nodes))
+	require.Equal(map[string]string{"node-a": "zone-a", "node-b": "zone-b"}, NodeZones(nodes))
+}
@@ -0,0 +1,43 @@
+package kubernetes
+
+import (
+	"io"
+	"net/http"
+
+	k8sclient "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/portforward"
+	"k8s.io/client-go/transport/spdy"
+)
+
+// NewPortForwarder returns a forwarder of the local ports to the pod, which runs until stop is closed or the
+// connection to the pod is lost. The ports use the "[local:]remote" format of kubectl port-forward, a local port 0
+// picks a free port. They are opened on the addresses and ready is closed once they accept connections. Errors of
+// the individual connections are written to errOut.
+func NewPortForwarder(restConfig *rest.Config, namespace, podName string, addresses, ports []string, stop <-chan struct{}, ready chan struct{}, errOut io.Writer) (*portforward.PortForwarder, error) {
+	clientset, err := k8sclient.NewForConfig(restConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	transport, upgrader, err := spdy.RoundTripperFor(restConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	url := clientset.CoreV1().RESTClient().Post().Resource("pods").Namespace(namespace).Name(podName).SubResource("portforward").URL()
+	dialer := spdy.NewDialer(upgrader, &http.Client{Transport: transport}, http.MethodPost, url)
+
+	return portforward.NewOnAddresses(dialer, addresses, ports, stop, ready, io.Discard, errOut)
+}
+
+// ForwardPorts forwards the local ports to the pod until stop is closed or the connection to the pod is lost, see
+// NewPortForwarder
+func ForwardPorts(restConfig *rest.Config, namespace, podName string, addresses, ports []string, stop <-chan struct{}, ready chan struct{}, errOut io.Writer) error {
+	forwarder, err := NewPortForwarder(restConfig, namespace, podName, addresses, ports, stop, ready, errOut)
+	if err != nil {
+		return err
+	}
+
+	return forwarder.ForwardPorts()
+}
@@ -0,0 +1,64 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/tools/cache"
+	watchtools "k8s.io/client-go/tools/watch"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ConditionFunc returns true when the watched object has reached the wanted state
+type ConditionFunc func(obj client.Object) (bool, error)
+
+// WaitForObject watches the object identified by key until condition returns true. obj and list must be the
+// typed object and its list type. Waiting ends with an error if the object is deleted or the context is done,
+// use a context with a deadline to limit the time spent waiting.
+func WaitForObject(ctx context.Context, cli client.WithWatch, key types.NamespacedName, obj client.Object, list client.ObjectList, condition ConditionFunc) error {
+	fieldSelector := fields.OneTermEqualSelector("metadata.name", key.Name)
+	lw := NewListWatch(ctx, cli, list, client.InNamespace(key.Namespace), client.MatchingFieldsSelector{Selector: fieldSelector})
+
+	_, err := watchtools.UntilWithSync(ctx, lw, obj, nil, func(event watch.Event) (bool, error) {
+		switch event.Type {
+		case watch.Deleted:
+			return false, errors.NewNotFound(schema.GroupResource{}, key.Name)
+		case watch.Added, watch.Modified:
+			o, ok := event.Object.(client.Object)
+			if !ok {
+				return false, fmt.Errorf("unexpected object type %T while waiting for %s", event.Object, key)
+			}
+			return condition(o)
+		}
+		return false, nil
+	})
+
+	if err != nil && ctx.Err() != nil {
+		return fmt.Errorf("gave up waiting for %s: %w", key, ctx.Err())
+	}
+
+	return err
+}
+
+// NewListWatch returns a cache.ListerWatcher for the list type using the controller-runtime client. The opts are
+// applied on top of the options requested by the informer.
+func NewListWatch(ctx context.Context, cli client.WithWatch, list client.ObjectList, opts ...client.ListOption) *cache.ListWatch {
+	return &cache.ListWatch{
+		ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+			objList := list.DeepCopyObject().(client.ObjectList)
+			err := cli.List(ctx, objList, append([]client.ListOption{&client.ListOptions{Raw: &options}}, opts...)...)
+			return objList, err
+		},
+		WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+			objList := list.DeepCopyObject().(client.ObjectList)
+			return cli.Watch(ctx, objList, append([]client.ListOption{&client.ListOptions{Raw: &options}}, opts...)...)
+		},
+	}
+}
@@ -3,17 +3,30 @@ package mgmtapi
 import (
 	"context"
 
+	cassdcapi "github.com/k8ssandra/cass-operator/apis/cassandra/v1beta1"
 	"github.com/k8ssandra/cass-operator/pkg/httphelper"
+	"k8s.io/client-go/rest"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 )
 
-// NewManagementClient returns a new instance for management-api go-client
-func NewManagementClient(ctx context.Context, client client.Client) (httphelper.NodeMgmtClient, error) {
+// restConfigGetter is implemented by the clients which know the configuration they were created with, such as
+// kubernetes.NamespacedClient
+type restConfigGetter interface {
+	Config() *rest.Config
+}
+
+// NewManagementClient returns a new instance for management-api go-client using the management API authentication
+// of the datacenter. With Spec.ManagementApiAuth.Manual the client certificate, key and CA are read from the client
+// secret of the datacenter. When running outside of the cluster, the pods are dialed through a Tunnel, which is
+// closed once the context is done.
+func NewManagementClient(ctx context.Context, client client.Client, dc *cassdcapi.CassandraDatacenter) (httphelper.NodeMgmtClient, error) {
 	logger := log.FromContext(ctx)
 
-	// We don't support authentication yet, so always use insecure
-	provider := &httphelper.InsecureManagementApiSecurityProvider{}
+	provider, err := httphelper.BuildManagementApiSecurityProvider(dc)
+	if err != nil {
+		return httphelper.NodeMgmtClient{}, err
+	}
 	protocol := provider.GetProtocol()
 
 	httpClient, err := provider.BuildHttpClient(client, ctx)
@@ -22,9 +35,27 @@ func NewManagementClient(ctx context.Context, client client.Client) (httphelper.
 		return httphelper.NodeMgmtClient{}, err
 	}
 
+	if configured, ok := client.(restConfigGetter); ok && configured.Config() != nil && !inCluster() {
+		tunnel := NewTunnel(configured.Config(), client)
+		httpClient, err = TunneledClient(httpClient, tunnel)
+		if err != nil {
+			return httphelper.NodeMgmtClient{}, err
+		}
+		go func() {
+			<-ctx.Done()
+			tunnel.Close()
+		}()
+	}
+
 	return httphelper.NodeMgmtClient{
 		Client:   httpClient,
 		Log:      logger,
 		Protocol: protocol,
 	}, nil
 }
+
+// inCluster returns true if the client runs in a pod, which can reach the other pods directly
+func inCluster() bool {
+	_, err := rest.InClusterConfig()
+	return err == nil
+}
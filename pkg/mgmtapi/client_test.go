@@ -0,0 +1,29 @@
+package mgmtapi
+
+import (
+	"context"
+	"testing"
+
+	cassdcapi "github.com/k8ssandra/cass-operator/apis/cassandra/v1beta1"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestNewManagementClientAuth(t *testing.T) {
+	require := require.New(t)
+
+	ctx := context.Background()
+	kubeClient := fake.NewClientBuilder().Build()
+
+	dc := &cassdcapi.CassandraDatacenter{ObjectMeta: metav1.ObjectMeta{Name: "dc1", Namespace: "cass"}}
+	mgmtClient, err := NewManagementClient(ctx, kubeClient, dc)
+	require.NoError(err)
+	require.Equal("http", mgmtClient.Protocol)
+
+	// The client certificate is read from the secret of the manual configuration
+	dc.Spec.ManagementApiAuth.Manual = &cassdcapi.ManagementApiAuthManualConfig{ClientSecretName: "mgmt-api-client"}
+	_, err = NewManagementClient(ctx, kubeClient, dc)
+	require.Error(err)
+	require.Contains(err.Error(), "mgmt-api-client")
+}
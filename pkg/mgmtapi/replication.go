@@ -0,0 +1,65 @@
+package mgmtapi
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/k8ssandra/cass-operator/pkg/httphelper"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// ReplicationFactors returns the replication factor of each keyspace in the given datacenter. Keyspaces which
+// are not replicated to the datacenter or use a strategy without a replication factor (such as LocalStrategy)
+// are not included.
+func ReplicationFactors(mgmtClient *httphelper.NodeMgmtClient, pod *corev1.Pod, datacenter string) (map[string]int, error) {
+	keyspaces, err := mgmtClient.ListKeyspaces(pod)
+	if err != nil {
+		return nil, err
+	}
+
+	factors := make(map[string]int, len(keyspaces))
+	for _, keyspace := range keyspaces {
+		replication, err := mgmtClient.GetKeyspaceReplication(pod, keyspace)
+		if err != nil {
+			return nil, err
+		}
+
+		rf, found, err := ReplicationFactor(replication, datacenter)
+		if err != nil {
+			return nil, fmt.Errorf("keyspace %s: %w", keyspace, err)
+		}
+		if found {
+			factors[keyspace] = rf
+		}
+	}
+
+	return factors, nil
+}
+
+// ReplicationFactor parses the replication factor for the datacenter from the keyspace replication settings
+func ReplicationFactor(replication map[string]string, datacenter string) (int, bool, error) {
+	var value string
+	var found bool
+
+	class := replication["class"]
+	switch {
+	case strings.HasSuffix(class, "NetworkTopologyStrategy"):
+		value, found = replication[datacenter]
+	case strings.HasSuffix(class, "SimpleStrategy"):
+		value, found = replication["replication_factor"]
+	}
+
+	if !found {
+		return 0, false, nil
+	}
+
+	// Transient replication is defined as total/transient, such as 3/1
+	value = strings.SplitN(value, "/", 2)[0]
+	rf, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil {
+		return 0, false, fmt.Errorf("unable to parse replication factor %s: %w", value, err)
+	}
+
+	return rf, true, nil
+}
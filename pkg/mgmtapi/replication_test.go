@@ -0,0 +1,37 @@
+package mgmtapi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestReplicationFactor(t *testing.T) {
+	require := require.New(t)
+
+	rf, found, err := ReplicationFactor(map[string]string{"class": "org.apache.cassandra.locator.NetworkTopologyStrategy", "dc1": "3", "dc2": "1"}, "dc1")
+	require.NoError(err)
+	require.True(found)
+	require.Equal(3, rf)
+
+	_, found, err = ReplicationFactor(map[string]string{"class": "org.apache.cassandra.locator.NetworkTopologyStrategy", "dc2": "1"}, "dc1")
+	require.NoError(err)
+	require.False(found)
+
+	rf, found, err = ReplicationFactor(map[string]string{"class": "org.apache.cassandra.locator.SimpleStrategy", "replication_factor": "2"}, "dc1")
+	require.NoError(err)
+	require.True(found)
+	require.Equal(2, rf)
+
+	rf, found, err = ReplicationFactor(map[string]string{"class": "org.apache.cassandra.locator.NetworkTopologyStrategy", "dc1": "3/1"}, "dc1")
+	require.NoError(err)
+	require.True(found)
+	require.Equal(3, rf)
+
+	_, found, err = ReplicationFactor(map[string]string{"class": "org.apache.cassandra.locator.LocalStrategy"}, "dc1")
+	require.NoError(err)
+	require.False(found)
+
+	_, _, err = ReplicationFactor(map[string]string{"class": "org.apache.cassandra.locator.NetworkTopologyStrategy", "dc1": "three"}, "dc1")
+	require.Error(err)
+}
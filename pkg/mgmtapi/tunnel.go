@@ -0,0 +1,173 @@
+package mgmtapi
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+
+	"github.com/k8ssandra/cass-operator/pkg/httphelper"
+	"github.com/k8ssandra/k8ssandra-client/pkg/kubernetes"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Tunnel dials the pods through port-forwards of the Kubernetes API server, which makes their management API
+// reachable from outside the cluster network. The pod is found by the IP address being dialed. A port-forward is
+// opened on the first connection to a pod and port and reused by the later ones until Close is called.
+type Tunnel struct {
+	restConfig *rest.Config
+	client     client.Client
+
+	lock     sync.Mutex
+	forwards map[string]*forward
+}
+
+type forward struct {
+	localAddress string
+	stop         chan struct{}
+	done         chan struct{}
+}
+
+// NewTunnel returns a Tunnel looking up the pods with the client
+func NewTunnel(restConfig *rest.Config, client client.Client) *Tunnel {
+	return &Tunnel{
+		restConfig: restConfig,
+		client:     client,
+		forwards:   make(map[string]*forward),
+	}
+}
+
+// DialContext connects to the address, which must be the IP address and port of a pod, through a port-forward. It
+// can be used as the DialContext of an http.Transport.
+func (t *Tunnel) DialContext(ctx context.Context, network, address string) (net.Conn, error) {
+	fwd, err := t.forward(ctx, address)
+	if err != nil {
+		return nil, err
+	}
+
+	var dialer net.Dialer
+	return dialer.DialContext(ctx, network, fwd.localAddress)
+}
+
+// Close stops all the port-forwards
+func (t *Tunnel) Close() {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	for address, fwd := range t.forwards {
+		close(fwd.stop)
+		delete(t.forwards, address)
+	}
+}
+
+// forward returns the running port-forward to the address or opens a new one
+func (t *Tunnel) forward(ctx context.Context, address string) (*forward, error) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if fwd, found := t.forwards[address]; found {
+		select {
+		case <-fwd.done:
+			// The connection to the pod was lost, it may have been restarted
+			delete(t.forwards, address)
+		default:
+			return fwd, nil
+		}
+	}
+
+	host, port, err := net.SplitHostPort(address)
+	if err != nil {
+		return nil, err
+	}
+
+	pod, err := t.podByIP(ctx, host)
+	if err != nil {
+		return nil, err
+	}
+
+	stop := make(chan struct{})
+	ready := make(chan struct{})
+	done := make(chan struct{})
+
+	// Port 0 picks a free local port, listening only on IPv4 to have a single port to dial
+	forwarder, err := kubernetes.NewPortForwarder(t.restConfig, pod.Namespace, pod.Name, []string{"127.0.0.1"}, []string{"0:" + port}, stop, ready, io.Discard)
+	if err != nil {
+		return nil, err
+	}
+
+	var forwardErr error
+	go func() {
+		forwardErr = forwarder.ForwardPorts()
+		close(done)
+	}()
+
+	select {
+	case <-ready:
+	case <-done:
+		return nil, fmt.Errorf("unable to forward port %s of pod %s: %v", port, pod.Name, forwardErr)
+	case <-ctx.Done():
+		close(stop)
+		return nil, ctx.Err()
+	}
+
+	ports, err := forwarder.GetPorts()
+	if err != nil {
+		close(stop)
+		return nil, err
+	}
+
+	fwd := &forward{
+		localAddress: net.JoinHostPort("127.0.0.1", strconv.Itoa(int(ports[0].Local))),
+		stop:         stop,
+		done:         done,
+	}
+	t.forwards[address] = fwd
+
+	return fwd, nil
+}
+
+// podByIP returns the running pod with the IP address
+func (t *Tunnel) podByIP(ctx context.Context, ip string) (*corev1.Pod, error) {
+	podList := &corev1.PodList{}
+	if err := t.client.List(ctx, podList, client.MatchingFields{"status.podIP": ip}); err != nil {
+		return nil, err
+	}
+
+	for i := range podList.Items {
+		if podList.Items[i].Status.Phase == corev1.PodRunning {
+			return &podList.Items[i], nil
+		}
+	}
+
+	return nil, fmt.Errorf("no running pod found with IP address %s", ip)
+}
+
+// TunneledClient returns a copy of the HTTP client which dials through the tunnel. Only *http.Client is supported,
+// its transport must be nil or an *http.Transport.
+func TunneledClient(httpClient httphelper.HttpClient, tunnel *Tunnel) (httphelper.HttpClient, error) {
+	c, ok := httpClient.(*http.Client)
+	if !ok {
+		return nil, fmt.Errorf("unable to tunnel the management API calls of %T", httpClient)
+	}
+
+	var transport *http.Transport
+	switch t := c.Transport.(type) {
+	case nil:
+		transport = http.DefaultTransport.(*http.Transport).Clone()
+	case *http.Transport:
+		transport = t.Clone()
+	default:
+		return nil, fmt.Errorf("unable to tunnel the management API calls of transport %T", c.Transport)
+	}
+	transport.Proxy = nil
+	transport.DialContext = tunnel.DialContext
+
+	tunneled := *c
+	tunneled.Transport = transport
+	return &tunneled, nil
+}
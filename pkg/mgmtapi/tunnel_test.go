@@ -0,0 +1,30 @@
+package mgmtapi
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTunneledClient(t *testing.T) {
+	require := require.New(t)
+
+	tunnel := NewTunnel(nil, nil)
+
+	httpClient, err := TunneledClient(http.DefaultClient, tunnel)
+	require.NoError(err)
+	require.Nil(http.DefaultClient.Transport)
+	require.NotNil(httpClient.(*http.Client).Transport.(*http.Transport).DialContext)
+
+	original := &http.Client{Timeout: time.Minute, Transport: &http.Transport{}}
+	httpClient, err = TunneledClient(original, tunnel)
+	require.NoError(err)
+	require.Nil(original.Transport.(*http.Transport).DialContext)
+	require.Equal(time.Minute, httpClient.(*http.Client).Timeout)
+	require.NotNil(httpClient.(*http.Client).Transport.(*http.Transport).DialContext)
+
+	_, err = TunneledClient(&http.Client{Transport: http.NewFileTransport(http.Dir("."))}, tunnel)
+	require.Error(err)
+}
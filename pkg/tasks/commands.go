@@ -0,0 +1,159 @@
+package tasks
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	controlapi "github.com/k8ssandra/cass-operator/apis/control/v1alpha1"
+)
+
+// commandSpec describes which JobArguments cass-operator uses when executing the command
+type commandSpec struct {
+	keyspace         bool
+	sourceDatacenter bool
+	pod              bool
+	rack             bool
+	required         []string
+}
+
+var (
+	commandSpecs = map[controlapi.CassandraCommand]commandSpec{
+		controlapi.CommandCleanup:         {keyspace: true},
+		controlapi.CommandRebuild:         {sourceDatacenter: true, required: []string{"source datacenter"}},
+		controlapi.CommandRestart:         {rack: true},
+		controlapi.CommandUpgradeSSTables: {keyspace: true},
+		controlapi.CommandReplaceNode:     {pod: true, required: []string{"pod"}},
+	}
+
+	// notImplementedCommands are part of the CassandraTask API, but are not processed by cass-operator
+	notImplementedCommands = map[string]controlapi.CassandraCommand{
+		"compact":    controlapi.CommandCompaction,
+		"compaction": controlapi.CommandCompaction,
+		"scrub":      controlapi.CommandScrub,
+	}
+
+	// unsupportedCommands have no CassandraTask command at all
+	unsupportedCommands = []string{"flush", "garbagecollect", "move"}
+)
+
+// SupportedCommands returns the names of the commands which can be run as a CassandraTask
+func SupportedCommands() []string {
+	commands := make([]string, 0, len(commandSpecs))
+	for command := range commandSpecs {
+		commands = append(commands, string(command))
+	}
+	sort.Strings(commands)
+	return commands
+}
+
+// CommandsHelp describes the supported commands with the flags of their arguments, optional ones in brackets, and
+// lists the commands which are not available with the CassandraTask API of cass-operator
+func CommandsHelp() string {
+	var sb strings.Builder
+	sb.WriteString("Supported commands and their arguments:\n")
+	for _, name := range SupportedCommands() {
+		spec := commandSpecs[controlapi.CassandraCommand(name)]
+		flags := make([]string, 0)
+		for _, arg := range []struct {
+			name    string
+			flag    string
+			allowed bool
+		}{
+			{"keyspace", "--keyspace", spec.keyspace},
+			{"source datacenter", "--source-datacenter", spec.sourceDatacenter},
+			{"pod", "--pod", spec.pod},
+			{"rack", "--rack", spec.rack},
+		} {
+			if !arg.allowed {
+				continue
+			}
+			flag := arg.flag
+			if !spec.requires(arg.name) {
+				flag = fmt.Sprintf("[%s]", flag)
+			}
+			flags = append(flags, flag)
+		}
+		fmt.Fprintf(&sb, "  %-16s %s\n", name, strings.Join(flags, " "))
+	}
+
+	notImplemented := make([]string, 0, len(notImplementedCommands))
+	for name := range notImplementedCommands {
+		notImplemented = append(notImplemented, name)
+	}
+	sort.Strings(notImplemented)
+
+	fmt.Fprintf(&sb, "\nNot available with this cass-operator version: %s (not implemented by cass-operator) and %s (no CassandraTask command). ",
+		strings.Join(notImplemented, ", "), strings.Join(unsupportedCommands, ", "))
+	sb.WriteString("Tables and new tokens can not be given as arguments.")
+	return sb.String()
+}
+
+func (s commandSpec) requires(arg string) bool {
+	for _, required := range s.required {
+		if required == arg {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseCommand returns the CassandraTask command with the given name or an error explaining why it can not be used
+func ParseCommand(name string) (controlapi.CassandraCommand, error) {
+	command := controlapi.CassandraCommand(strings.ToLower(name))
+	if _, found := commandSpecs[command]; found {
+		return command, nil
+	}
+
+	if _, found := notImplementedCommands[string(command)]; found {
+		return "", fmt.Errorf("command %s is defined in the CassandraTask API, but cass-operator does not implement it", name)
+	}
+
+	for _, unsupported := range unsupportedCommands {
+		if string(command) == unsupported {
+			return "", fmt.Errorf("command %s is not supported by the CassandraTask API", name)
+		}
+	}
+
+	return "", fmt.Errorf("unknown command %s, supported commands are: %s", name, strings.Join(SupportedCommands(), ", "))
+}
+
+// ValidateArguments verifies the arguments are used by the command and all the required ones are set
+func ValidateArguments(command controlapi.CassandraCommand, args *controlapi.JobArguments) error {
+	spec, found := commandSpecs[command]
+	if !found {
+		return fmt.Errorf("unknown command %s", command)
+	}
+
+	if args == nil {
+		args = &controlapi.JobArguments{}
+	}
+
+	given := map[string]bool{
+		"keyspace":          args.KeyspaceName != "",
+		"source datacenter": args.SourceDatacenter != "",
+		"pod":               args.PodName != "",
+		"rack":              args.RackName != "",
+	}
+
+	allowed := map[string]bool{
+		"keyspace":          spec.keyspace,
+		"source datacenter": spec.sourceDatacenter,
+		"pod":               spec.pod,
+		"rack":              spec.rack,
+	}
+
+	for _, arg := range []string{"keyspace", "source datacenter", "pod", "rack"} {
+		if given[arg] && !allowed[arg] {
+			return fmt.Errorf("command %s does not accept a %s argument", command, arg)
+		}
+	}
+
+	for _, arg := range spec.required {
+		if !given[arg] {
+			return fmt.Errorf("command %s requires a %s argument", command, arg)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,53 @@
+package tasks
+
+import (
+	"testing"
+
+	controlapi "github.com/k8ssandra/cass-operator/apis/control/v1alpha1"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCommand(t *testing.T) {
+	require := require.New(t)
+
+	command, err := ParseCommand("cleanup")
+	require.NoError(err)
+	require.Equal(controlapi.CommandCleanup, command)
+
+	command, err = ParseCommand("UpgradeSSTables")
+	require.NoError(err)
+	require.Equal(controlapi.CommandUpgradeSSTables, command)
+
+	_, err = ParseCommand("scrub")
+	require.ErrorContains(err, "does not implement")
+
+	_, err = ParseCommand("flush")
+	require.ErrorContains(err, "not supported")
+
+	_, err = ParseCommand("repair")
+	require.ErrorContains(err, "unknown command")
+}
+
+func TestValidateArguments(t *testing.T) {
+	require := require.New(t)
+
+	require.NoError(ValidateArguments(controlapi.CommandCleanup, nil))
+	require.NoError(ValidateArguments(controlapi.CommandCleanup, &controlapi.JobArguments{KeyspaceName: "ks"}))
+	require.NoError(ValidateArguments(controlapi.CommandRestart, &controlapi.JobArguments{RackName: "r1"}))
+	require.NoError(ValidateArguments(controlapi.CommandRebuild, &controlapi.JobArguments{SourceDatacenter: "dc2"}))
+
+	require.Error(ValidateArguments(controlapi.CommandCleanup, &controlapi.JobArguments{RackName: "r1"}))
+	require.Error(ValidateArguments(controlapi.CommandRebuild, nil))
+	require.Error(ValidateArguments(controlapi.CommandReplaceNode, &controlapi.JobArguments{}))
+	require.Error(ValidateArguments(controlapi.CommandRestart, &controlapi.JobArguments{KeyspaceName: "ks"}))
+}
+
+func TestCommandsHelp(t *testing.T) {
+	require := require.New(t)
+
+	help := CommandsHelp()
+	require.Contains(help, "  rebuild          --source-datacenter\n")
+	require.Contains(help, "  restart          [--rack]\n")
+	require.Contains(help, "scrub")
+	require.Contains(help, "flush, garbagecollect, move")
+}
@@ -3,29 +3,74 @@ package tasks
 import (
 	"context"
 	"fmt"
+	"os/user"
+	"regexp"
+	"strings"
+	"time"
 
 	cassdcapi "github.com/k8ssandra/cass-operator/apis/cassandra/v1beta1"
 	controlapi "github.com/k8ssandra/cass-operator/apis/control/v1alpha1"
+	"github.com/k8ssandra/k8ssandra-client/pkg/kubernetes"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilrand "k8s.io/apimachinery/pkg/util/rand"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+const (
+	// CommandLabel is set on the tasks created by this client to the command of the task
+	CommandLabel = "k8ssandra.io/task-command"
+	// InitiatorLabel is set on the tasks created by this client to the user who created the task
+	InitiatorLabel = "k8ssandra.io/task-initiator"
+)
+
+var invalidLabelChars = regexp.MustCompile(`[^a-zA-Z0-9_.-]+`)
+
+// TaskInProgressError is returned when a task with the same command is already running on the datacenter
+type TaskInProgressError struct {
+	Task       string
+	Command    controlapi.CassandraCommand
+	Datacenter string
+}
+
+func (e *TaskInProgressError) Error() string {
+	return fmt.Sprintf("task %s is already running %s on datacenter %s", e.Task, e.Command, e.Datacenter)
+}
+
+// TaskOptions are the optional scheduling settings of a created task. Unset fields use the cass-operator defaults.
+type TaskOptions struct {
+	// ScheduledTime is the earliest time the task is started
+	ScheduledTime *time.Time
+	// TTLSecondsAfterFinished is how long the task is kept after it has completed, 0 keeps it forever
+	TTLSecondsAfterFinished *int32
+	// ConcurrencyPolicy allows the task to run at the same time as other tasks that allow it
+	ConcurrencyPolicy batchv1.ConcurrencyPolicy
+	// DryRun returns the task without persisting it, with server dry-run it is still validated by the API server
+	DryRun kubernetes.DryRunStrategy
+}
+
 func CreateRestartTask(ctx context.Context, kubeClient client.Client, dc *cassdcapi.CassandraDatacenter, rackName string) (*controlapi.CassandraTask, error) {
 	args := controlapi.JobArguments{}
 	if rackName != "" {
 		args.RackName = rackName
 	}
 
-	return CreateTask(ctx, kubeClient, controlapi.CommandRestart, dc, &args)
+	return CreateTask(ctx, kubeClient, controlapi.CommandRestart, dc, &args, nil)
 }
 
-func CreateTask(ctx context.Context, kubeClient client.Client, command controlapi.CassandraCommand, dc *cassdcapi.CassandraDatacenter, args *controlapi.JobArguments) (*controlapi.CassandraTask, error) {
-	generatedName := fmt.Sprintf("%s-command-time?", dc.Name)
+// CreateTask creates a CassandraTask running the command in the datacenter. The task is labeled with the datacenter,
+// the command and the local user who created it.
+func CreateTask(ctx context.Context, kubeClient client.Client, command controlapi.CassandraCommand, dc *cassdcapi.CassandraDatacenter, args *controlapi.JobArguments, opts *TaskOptions) (*controlapi.CassandraTask, error) {
 	task := &controlapi.CassandraTask{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      generatedName,
+			Name:      TaskName(dc.Name, command, time.Now()),
 			Namespace: dc.Namespace,
+			Labels: map[string]string{
+				cassdcapi.DatacenterLabel: cassdcapi.CleanLabelValue(dc.Name),
+				CommandLabel:              string(command),
+				InitiatorLabel:            Initiator(),
+			},
 		},
 		Spec: controlapi.CassandraTaskSpec{
 			Datacenter: corev1.ObjectReference{
@@ -44,9 +89,99 @@ func CreateTask(ctx context.Context, kubeClient client.Client, command controlap
 		task.Spec.Jobs[0].Arguments = *args
 	}
 
-	if err := kubeClient.Create(ctx, task); err != nil {
+	createOpts := []client.CreateOption{client.FieldOwner(kubernetes.FieldManager)}
+
+	if opts != nil {
+		if opts.ScheduledTime != nil {
+			scheduledTime := metav1.NewTime(*opts.ScheduledTime)
+			task.Spec.ScheduledTime = &scheduledTime
+		}
+		task.Spec.TTLSecondsAfterFinished = opts.TTLSecondsAfterFinished
+		task.Spec.ConcurrencyPolicy = opts.ConcurrencyPolicy
+
+		switch opts.DryRun {
+		case kubernetes.DryRunClient:
+			return task, nil
+		case kubernetes.DryRunServer:
+			createOpts = append(createOpts, client.DryRunAll)
+		}
+	}
+
+	if err := kubeClient.Create(ctx, task, createOpts...); err != nil {
 		return nil, err
 	}
 
 	return task, nil
 }
+
+// TaskName generates a unique task name from the datacenter, command, creation time and a random suffix
+func TaskName(dcName string, command controlapi.CassandraCommand, now time.Time) string {
+	return fmt.Sprintf("%s-%s-%s-%s", dcName, command, now.UTC().Format("20060102-150405"), utilrand.String(5))
+}
+
+// Initiator returns the local username as a valid label value, or "unknown" if it is not available
+func Initiator() string {
+	current, err := user.Current()
+	if err != nil {
+		return "unknown"
+	}
+
+	initiator := strings.Trim(invalidLabelChars.ReplaceAllString(current.Username, "_"), "_.-")
+	if len(initiator) > 63 {
+		initiator = strings.Trim(initiator[:63], "_.-")
+	}
+	if initiator == "" {
+		return "unknown"
+	}
+	return initiator
+}
+
+// ActiveTask returns the first task from the list which targets the datacenter, has a job with the command and
+// is in flight, or nil if there is no such task. A task is in flight if it has not completed and has either been
+// started by cass-operator or its scheduled time has passed.
+func ActiveTask(taskList *controlapi.CassandraTaskList, dcName string, command controlapi.CassandraCommand, now time.Time) *controlapi.CassandraTask {
+	for i := range taskList.Items {
+		task := &taskList.Items[i]
+		if task.Status.CompletionTime == nil && hasCommand(task, dcName, command) && taskStarted(task, now) {
+			return task
+		}
+	}
+	return nil
+}
+
+// PendingTasks returns the tasks from the list which target the datacenter, have a job with the command and are
+// waiting for their scheduled time or for cass-operator to pick them up
+func PendingTasks(taskList *controlapi.CassandraTaskList, dcName string, command controlapi.CassandraCommand, now time.Time) []controlapi.CassandraTask {
+	pending := make([]controlapi.CassandraTask, 0)
+	for i := range taskList.Items {
+		task := &taskList.Items[i]
+		if task.Status.CompletionTime == nil && hasCommand(task, dcName, command) && !taskStarted(task, now) {
+			pending = append(pending, *task)
+		}
+	}
+	return pending
+}
+
+// DescribePendingTask returns a single line description of a task returned by PendingTasks
+func DescribePendingTask(task *controlapi.CassandraTask) string {
+	if task.Spec.ScheduledTime != nil {
+		return fmt.Sprintf("CassandraTask %s is scheduled to run at %s", task.Name, task.Spec.ScheduledTime.Format(time.RFC3339))
+	}
+	return fmt.Sprintf("CassandraTask %s has not been started by cass-operator yet", task.Name)
+}
+
+func hasCommand(task *controlapi.CassandraTask, dcName string, command controlapi.CassandraCommand) bool {
+	if task.Spec.Datacenter.Name != dcName {
+		return false
+	}
+	for _, job := range task.Spec.Jobs {
+		if job.Command == command {
+			return true
+		}
+	}
+	return false
+}
+
+func taskStarted(task *controlapi.CassandraTask, now time.Time) bool {
+	return task.Status.StartTime != nil || (task.Spec.ScheduledTime != nil && !task.Spec.ScheduledTime.After(now))
+}
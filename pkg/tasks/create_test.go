@@ -0,0 +1,75 @@
+package tasks
+
+import (
+	"testing"
+	"time"
+
+	controlapi "github.com/k8ssandra/cass-operator/apis/control/v1alpha1"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+func TestTaskName(t *testing.T) {
+	require := require.New(t)
+
+	now := time.Date(2022, 10, 18, 2, 0, 0, 0, time.UTC)
+	name := TaskName("dc1", controlapi.CommandRestart, now)
+	require.Regexp(`^dc1-restart-20221018-020000-[a-z0-9]{5}$`, name)
+	require.Empty(validation.IsDNS1123Subdomain(name))
+	require.NotEqual(name, TaskName("dc1", controlapi.CommandRestart, now))
+
+	require.Empty(validation.IsValidLabelValue(Initiator()))
+}
+
+func TestActiveTask(t *testing.T) {
+	require := require.New(t)
+
+	now := time.Now()
+
+	task := func(name, dc string, command controlapi.CassandraCommand, completed bool) controlapi.CassandraTask {
+		task := controlapi.CassandraTask{ObjectMeta: metav1.ObjectMeta{Name: name}}
+		task.Spec.Datacenter.Name = dc
+		task.Spec.Jobs = []controlapi.CassandraJob{{Name: name, Command: command}}
+		started := metav1.NewTime(now.Add(-time.Minute))
+		task.Status.StartTime = &started
+		if completed {
+			completion := metav1.NewTime(now)
+			task.Status.CompletionTime = &completion
+		}
+		return task
+	}
+
+	scheduled := func(name string, scheduledTime time.Time) controlapi.CassandraTask {
+		task := task(name, "dc3", controlapi.CommandCleanup, false)
+		task.Status.StartTime = nil
+		if !scheduledTime.IsZero() {
+			at := metav1.NewTime(scheduledTime)
+			task.Spec.ScheduledTime = &at
+		}
+		return task
+	}
+
+	taskList := &controlapi.CassandraTaskList{Items: []controlapi.CassandraTask{
+		task("restart-done", "dc1", controlapi.CommandRestart, true),
+		task("restart-dc2", "dc2", controlapi.CommandRestart, false),
+		task("cleanup-dc1", "dc1", controlapi.CommandCleanup, false),
+		scheduled("cleanup-tonight", now.Add(time.Hour)),
+		scheduled("cleanup-not-picked-up", time.Time{}),
+	}}
+
+	require.Nil(ActiveTask(taskList, "dc1", controlapi.CommandRestart, now))
+	require.Equal("restart-dc2", ActiveTask(taskList, "dc2", controlapi.CommandRestart, now).Name)
+	require.Equal("cleanup-dc1", ActiveTask(taskList, "dc1", controlapi.CommandCleanup, now).Name)
+	require.Empty(PendingTasks(taskList, "dc1", controlapi.CommandCleanup, now))
+
+	// Tasks which have not started are only in flight once their scheduled time has passed
+	require.Nil(ActiveTask(taskList, "dc3", controlapi.CommandCleanup, now))
+	pending := PendingTasks(taskList, "dc3", controlapi.CommandCleanup, now)
+	require.Len(pending, 2)
+	require.Equal("cleanup-tonight", pending[0].Name)
+	require.Contains(DescribePendingTask(&pending[0]), "scheduled to run at")
+	require.Equal("cleanup-not-picked-up", pending[1].Name)
+
+	require.Equal("cleanup-tonight", ActiveTask(taskList, "dc3", controlapi.CommandCleanup, now.Add(2*time.Hour)).Name)
+}
@@ -0,0 +1,105 @@
+package tasks
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	cassdcapi "github.com/k8ssandra/cass-operator/apis/cassandra/v1beta1"
+	controlapi "github.com/k8ssandra/cass-operator/apis/control/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// podJobAnnotationPrefix is the prefix of the pod annotation cass-operator uses to track the job of a task
+	podJobAnnotationPrefix = "control.k8ssandra.io/job"
+	podJobError            = "ERROR"
+)
+
+// podJobStatus is the job state cass-operator stores in the pod annotation while the task is running
+type podJobStatus struct {
+	Id      string `json:"id,omitempty"`
+	Status  string `json:"status,omitempty"`
+	Handler string `json:"handler,omitempty"`
+	Retries int    `json:"retries,omitempty"`
+}
+
+// TaskFailedError is returned when a CassandraTask completed, but failed on one or more pods
+type TaskFailedError struct {
+	Task    string
+	Failed  int
+	Message string
+	// Pods lists the failed pods with their rack, if they were seen while the task was running
+	Pods []string
+}
+
+func (e *TaskFailedError) Error() string {
+	msg := fmt.Sprintf("task %s failed", e.Task)
+	if e.Failed > 0 {
+		msg = fmt.Sprintf("%s on %d pod(s)", msg, e.Failed)
+	}
+	if len(e.Pods) > 0 {
+		msg = fmt.Sprintf("%s: %s", msg, strings.Join(e.Pods, ", "))
+	}
+	if e.Message != "" {
+		msg = fmt.Sprintf("%s: %s", msg, e.Message)
+	}
+	return msg
+}
+
+// TaskError returns a *TaskFailedError if the task has failed on any pod or has a Failed condition
+func TaskError(task *controlapi.CassandraTask, failedPods []string) error {
+	failedCondition := false
+	message := ""
+	for _, condition := range task.Status.Conditions {
+		if condition.Type == controlapi.JobFailed && condition.Status == corev1.ConditionTrue {
+			failedCondition = true
+			message = condition.Message
+		}
+	}
+
+	if task.Status.Failed == 0 && !failedCondition {
+		return nil
+	}
+
+	return &TaskFailedError{
+		Task:    task.Name,
+		Failed:  task.Status.Failed,
+		Message: message,
+		Pods:    failedPods,
+	}
+}
+
+// FailedPods returns the pods which have an errored job of the task in their annotations, named as "pod (rack)".
+// cass-operator removes the annotations when the task completes, so this only works while the task is running.
+func FailedPods(task *controlapi.CassandraTask, pods []corev1.Pod) []string {
+	failed := make([]string, 0)
+	for i := range pods {
+		if jobStatus, found := podJob(task, &pods[i]); found && jobStatus.Status == podJobError {
+			failed = append(failed, failedPodName(&pods[i]))
+		}
+	}
+
+	sort.Strings(failed)
+	return failed
+}
+
+// podJob returns the state of the task's job stored in the pod annotation, if the pod has one
+func podJob(task *controlapi.CassandraTask, pod *corev1.Pod) (podJobStatus, bool) {
+	var jobStatus podJobStatus
+
+	jobData, found := pod.Annotations[fmt.Sprintf("%s-%s", podJobAnnotationPrefix, task.UID)]
+	if !found {
+		return jobStatus, false
+	}
+
+	if err := json.Unmarshal([]byte(jobData), &jobStatus); err != nil {
+		return jobStatus, false
+	}
+	return jobStatus, true
+}
+
+func failedPodName(pod *corev1.Pod) string {
+	return fmt.Sprintf("%s (rack %s)", pod.Name, pod.Labels[cassdcapi.RackLabel])
+}
@@ -0,0 +1,52 @@
+package tasks
+
+import (
+	"errors"
+	"testing"
+
+	cassdcapi "github.com/k8ssandra/cass-operator/apis/cassandra/v1beta1"
+	controlapi "github.com/k8ssandra/cass-operator/apis/control/v1alpha1"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestFailedPods(t *testing.T) {
+	require := require.New(t)
+
+	task := &controlapi.CassandraTask{ObjectMeta: metav1.ObjectMeta{Name: "cleanup", UID: "1234"}}
+	pod := func(name, rack, job string) corev1.Pod {
+		p := corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{cassdcapi.RackLabel: rack}}}
+		if job != "" {
+			p.Annotations = map[string]string{"control.k8ssandra.io/job-1234": job}
+		}
+		return p
+	}
+
+	pods := []corev1.Pod{
+		pod("dc1-r2-sts-0", "r2", `{"id":"a","status":"ERROR","handler":"management-api"}`),
+		pod("dc1-r1-sts-0", "r1", `{"id":"b","status":"COMPLETED","handler":"management-api"}`),
+		pod("dc1-r1-sts-1", "r1", `{"id":"c","status":"ERROR","handler":"management-api"}`),
+		pod("dc1-r3-sts-0", "r3", ""),
+	}
+
+	require.Equal([]string{"dc1-r1-sts-1 (rack r1)", "dc1-r2-sts-0 (rack r2)"}, FailedPods(task, pods))
+}
+
+func TestTaskError(t *testing.T) {
+	require := require.New(t)
+
+	task := &controlapi.CassandraTask{ObjectMeta: metav1.ObjectMeta{Name: "cleanup"}}
+	task.Status.Succeeded = 3
+	require.NoError(TaskError(task, nil))
+
+	task.Status.Failed = 1
+	err := TaskError(task, []string{"dc1-r1-sts-1 (rack r1)"})
+	var failedErr *TaskFailedError
+	require.True(errors.As(err, &failedErr))
+	require.Equal("task cleanup failed on 1 pod(s): dc1-r1-sts-1 (rack r1)", err.Error())
+
+	task.Status.Failed = 0
+	task.Status.Conditions = []controlapi.JobCondition{{Type: controlapi.JobFailed, Status: corev1.ConditionTrue, Message: "replace failed"}}
+	require.EqualError(TaskError(task, nil), "task cleanup failed: replace failed")
+}
@@ -0,0 +1,95 @@
+package tasks
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/spf13/pflag"
+	batchv1 "k8s.io/api/batch/v1"
+)
+
+// TaskFlags are the command line flags for the scheduling settings of created tasks
+type TaskFlags struct {
+	schedule          string
+	ttl               string
+	concurrencyPolicy string
+}
+
+func NewTaskFlags() *TaskFlags {
+	return &TaskFlags{}
+}
+
+// AddFlags registers the task scheduling flags
+func (f *TaskFlags) AddFlags(flags *pflag.FlagSet) {
+	flags.StringVar(&f.schedule, "schedule", "", "earliest start time of the task, RFC3339 or HH:MM for the next occurrence of that local time")
+	flags.StringVar(&f.ttl, "ttl", "", "how long the task is kept after it has finished, e.g. 12h, 0 keeps it forever (cass-operator default 24h)")
+	flags.StringVar(&f.concurrencyPolicy, "concurrency-policy", "", "Forbid (cass-operator default) or Allow running at the same time as other tasks which allow it, Allow also skips the check for a running task with the same command")
+}
+
+// ToOptions parses the flag values to TaskOptions, relative schedules are resolved from now
+func (f *TaskFlags) ToOptions(now time.Time) (*TaskOptions, error) {
+	opts := &TaskOptions{}
+
+	if f.schedule != "" {
+		scheduledTime, err := ParseScheduledTime(f.schedule, now)
+		if err != nil {
+			return nil, err
+		}
+		opts.ScheduledTime = &scheduledTime
+	}
+
+	if f.ttl != "" {
+		ttl, err := time.ParseDuration(f.ttl)
+		if err != nil {
+			return nil, fmt.Errorf("invalid ttl %s: %w", f.ttl, err)
+		}
+		if ttl < 0 || ttl.Seconds() > math.MaxInt32 {
+			return nil, fmt.Errorf("ttl %s is out of range", f.ttl)
+		}
+		seconds := int32(ttl.Seconds())
+		opts.TTLSecondsAfterFinished = &seconds
+	}
+
+	switch strings.ToLower(f.concurrencyPolicy) {
+	case "":
+	case "allow":
+		opts.ConcurrencyPolicy = batchv1.AllowConcurrent
+	case "forbid":
+		opts.ConcurrencyPolicy = batchv1.ForbidConcurrent
+	default:
+		return nil, fmt.Errorf("invalid concurrency policy %s, must be Allow or Forbid", f.concurrencyPolicy)
+	}
+
+	return opts, nil
+}
+
+// ParseScheduledTime parses an RFC3339 timestamp, a local "2006-01-02 15:04" time or a local "15:04" time of day,
+// which is resolved to its next occurrence after now. Times in the past are rejected.
+func ParseScheduledTime(value string, now time.Time) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return notInPast(value, t, now)
+	}
+
+	if t, err := time.ParseInLocation("2006-01-02 15:04", value, now.Location()); err == nil {
+		return notInPast(value, t, now)
+	}
+
+	if t, err := time.ParseInLocation("15:04", value, now.Location()); err == nil {
+		scheduled := time.Date(now.Year(), now.Month(), now.Day(), t.Hour(), t.Minute(), 0, 0, now.Location())
+		if !scheduled.After(now) {
+			scheduled = scheduled.AddDate(0, 0, 1)
+		}
+		return scheduled, nil
+	}
+
+	return time.Time{}, fmt.Errorf("invalid schedule %s, use RFC3339, \"YYYY-MM-DD HH:MM\" or \"HH:MM\"", value)
+}
+
+func notInPast(value string, t, now time.Time) (time.Time, error) {
+	if t.Before(now) {
+		return time.Time{}, fmt.Errorf("scheduled time %s is in the past", value)
+	}
+	return t, nil
+}
@@ -0,0 +1,62 @@
+package tasks
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	batchv1 "k8s.io/api/batch/v1"
+)
+
+func TestParseScheduledTime(t *testing.T) {
+	require := require.New(t)
+
+	now := time.Date(2022, 10, 18, 14, 30, 0, 0, time.UTC)
+
+	scheduled, err := ParseScheduledTime("02:00", now)
+	require.NoError(err)
+	require.Equal(time.Date(2022, 10, 19, 2, 0, 0, 0, time.UTC), scheduled)
+
+	scheduled, err = ParseScheduledTime("15:00", now)
+	require.NoError(err)
+	require.Equal(time.Date(2022, 10, 18, 15, 0, 0, 0, time.UTC), scheduled)
+
+	scheduled, err = ParseScheduledTime("2022-10-20 02:00", now)
+	require.NoError(err)
+	require.Equal(time.Date(2022, 10, 20, 2, 0, 0, 0, time.UTC), scheduled)
+
+	scheduled, err = ParseScheduledTime("2022-10-20T02:00:00+03:00", now)
+	require.NoError(err)
+	require.True(scheduled.Equal(time.Date(2022, 10, 19, 23, 0, 0, 0, time.UTC)))
+
+	_, err = ParseScheduledTime("2022-10-17 02:00", now)
+	require.Error(err)
+
+	_, err = ParseScheduledTime("tomorrow", now)
+	require.Error(err)
+}
+
+func TestTaskFlagsToOptions(t *testing.T) {
+	require := require.New(t)
+
+	opts, err := (&TaskFlags{}).ToOptions(time.Now())
+	require.NoError(err)
+	require.Nil(opts.ScheduledTime)
+	require.Nil(opts.TTLSecondsAfterFinished)
+	require.Empty(opts.ConcurrencyPolicy)
+
+	opts, err = (&TaskFlags{ttl: "2h", concurrencyPolicy: "allow"}).ToOptions(time.Now())
+	require.NoError(err)
+	require.Equal(int32(7200), *opts.TTLSecondsAfterFinished)
+	require.Equal(batchv1.AllowConcurrent, opts.ConcurrencyPolicy)
+
+	opts, err = (&TaskFlags{ttl: "0"}).ToOptions(time.Now())
+	require.NoError(err)
+	require.Equal(int32(0), *opts.TTLSecondsAfterFinished)
+
+	_, err = (&TaskFlags{ttl: "-1h"}).ToOptions(time.Now())
+	require.Error(err)
+
+	_, err = (&TaskFlags{concurrencyPolicy: "Replace"}).ToOptions(time.Now())
+	require.Error(err)
+}
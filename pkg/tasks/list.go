@@ -0,0 +1,34 @@
+package tasks
+
+import (
+	"context"
+	"sort"
+
+	controlapi "github.com/k8ssandra/cass-operator/apis/control/v1alpha1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ListTasks returns the CassandraTasks targeting the datacenter, or all the tasks if datacenter is empty. The tasks
+// are sorted by their creation time.
+func ListTasks(ctx context.Context, kubeClient client.Client, datacenter string) (*controlapi.CassandraTaskList, error) {
+	taskList := &controlapi.CassandraTaskList{}
+	if err := kubeClient.List(ctx, taskList); err != nil {
+		return nil, err
+	}
+
+	if datacenter != "" {
+		items := make([]controlapi.CassandraTask, 0, len(taskList.Items))
+		for _, task := range taskList.Items {
+			if task.Spec.Datacenter.Name == datacenter {
+				items = append(items, task)
+			}
+		}
+		taskList.Items = items
+	}
+
+	sort.SliceStable(taskList.Items, func(i, j int) bool {
+		return taskList.Items[i].CreationTimestamp.Before(&taskList.Items[j].CreationTimestamp)
+	})
+
+	return taskList, nil
+}
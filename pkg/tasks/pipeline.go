@@ -0,0 +1,82 @@
+package tasks
+
+import (
+	"fmt"
+	"strings"
+
+	controlapi "github.com/k8ssandra/cass-operator/apis/control/v1alpha1"
+	"sigs.k8s.io/yaml"
+)
+
+// ParseJob parses a job definition of the form "command[,argument=value...]". The accepted arguments are keyspace,
+// source-datacenter, pod and rack.
+func ParseJob(definition string) (controlapi.CassandraJob, error) {
+	parts := strings.Split(definition, ",")
+
+	command, err := ParseCommand(strings.TrimSpace(parts[0]))
+	if err != nil {
+		return controlapi.CassandraJob{}, err
+	}
+
+	job := controlapi.CassandraJob{Command: command}
+	for _, part := range parts[1:] {
+		key, value, found := strings.Cut(part, "=")
+		if !found || value == "" {
+			return job, fmt.Errorf("invalid job argument %s, expected argument=value", part)
+		}
+
+		switch strings.TrimSpace(key) {
+		case "keyspace":
+			job.Arguments.KeyspaceName = value
+		case "source-datacenter":
+			job.Arguments.SourceDatacenter = value
+		case "pod":
+			job.Arguments.PodName = value
+		case "rack":
+			job.Arguments.RackName = value
+		default:
+			return job, fmt.Errorf("unknown job argument %s, expected one of keyspace, source-datacenter, pod or rack", key)
+		}
+	}
+
+	return job, ValidateArguments(job.Command, &job.Arguments)
+}
+
+// ParseTaskManifest reads a CassandraTask from YAML or JSON and verifies every job can be executed
+func ParseTaskManifest(data []byte) (*controlapi.CassandraTask, error) {
+	task := &controlapi.CassandraTask{}
+	if err := yaml.UnmarshalStrict(data, task); err != nil {
+		return nil, fmt.Errorf("unable to parse the CassandraTask: %w", err)
+	}
+
+	if task.Kind != "" && task.Kind != "CassandraTask" {
+		return nil, fmt.Errorf("expected a CassandraTask, got %s", task.Kind)
+	}
+
+	if len(task.Spec.Jobs) == 0 {
+		return nil, fmt.Errorf("the CassandraTask has no jobs")
+	}
+
+	for i := range task.Spec.Jobs {
+		job := &task.Spec.Jobs[i]
+		command, err := ParseCommand(string(job.Command))
+		if err != nil {
+			return nil, fmt.Errorf("job %d: %w", i+1, err)
+		}
+		job.Command = command
+
+		if err := ValidateArguments(job.Command, &job.Arguments); err != nil {
+			return nil, fmt.Errorf("job %d: %w", i+1, err)
+		}
+	}
+
+	return task, nil
+}
+
+// JobName returns the name of the job, or a name derived from its position and command if it has none
+func JobName(index int, job controlapi.CassandraJob) string {
+	if job.Name != "" {
+		return job.Name
+	}
+	return fmt.Sprintf("%d-%s", index+1, job.Command)
+}
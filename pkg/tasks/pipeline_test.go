@@ -0,0 +1,81 @@
+package tasks
+
+import (
+	"testing"
+
+	controlapi "github.com/k8ssandra/cass-operator/apis/control/v1alpha1"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseJob(t *testing.T) {
+	require := require.New(t)
+
+	job, err := ParseJob("cleanup")
+	require.NoError(err)
+	require.Equal(controlapi.CommandCleanup, job.Command)
+
+	job, err = ParseJob("upgradesstables,keyspace=ks1")
+	require.NoError(err)
+	require.Equal(controlapi.CommandUpgradeSSTables, job.Command)
+	require.Equal("ks1", job.Arguments.KeyspaceName)
+
+	job, err = ParseJob("rebuild,source-datacenter=dc2")
+	require.NoError(err)
+	require.Equal("dc2", job.Arguments.SourceDatacenter)
+
+	_, err = ParseJob("flush")
+	require.Error(err)
+
+	_, err = ParseJob("cleanup,tables=t1")
+	require.Error(err)
+
+	_, err = ParseJob("cleanup,keyspace")
+	require.Error(err)
+
+	_, err = ParseJob("cleanup,rack=r1")
+	require.Error(err)
+}
+
+func TestParseTaskManifest(t *testing.T) {
+	require := require.New(t)
+
+	task, err := ParseTaskManifest([]byte(`
+apiVersion: control.k8ssandra.io/v1alpha1
+kind: CassandraTask
+metadata:
+  name: maintenance
+spec:
+  datacenter:
+    name: dc1
+  ttlSecondsAfterFinished: 3600
+  jobs:
+    - name: upgrade
+      command: upgradesstables
+    - command: cleanup
+      args:
+        keyspace_name: ks1
+`))
+	require.NoError(err)
+	require.Equal("dc1", task.Spec.Datacenter.Name)
+	require.Len(task.Spec.Jobs, 2)
+	require.Equal("upgrade", JobName(0, task.Spec.Jobs[0]))
+	require.Equal("2-cleanup", JobName(1, task.Spec.Jobs[1]))
+	require.Equal("ks1", task.Spec.Jobs[1].Arguments.KeyspaceName)
+
+	_, err = ParseTaskManifest([]byte(`
+kind: CassandraTask
+spec:
+  datacenter:
+    name: dc1
+  jobs:
+    - command: flush
+`))
+	require.Error(err)
+
+	_, err = ParseTaskManifest([]byte(`
+kind: CassandraDatacenter
+spec:
+  size: 3
+`))
+	require.Error(err)
+}
@@ -2,30 +2,166 @@ package tasks
 
 import (
 	"context"
+	"sort"
+	"sync"
 	"time"
 
+	cassdcapi "github.com/k8ssandra/cass-operator/apis/cassandra/v1beta1"
 	controlapi "github.com/k8ssandra/cass-operator/apis/control/v1alpha1"
+	"github.com/k8ssandra/k8ssandra-client/pkg/kubernetes"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
-	waitutil "k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/cache"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
-func WaitForCompletion(ctx context.Context, kubeClient client.Client, task *controlapi.CassandraTask) error {
+const (
+	// jobCleanupTimeout limits the wait for the pod watch to observe the removal of the job annotations after the
+	// task has completed
+	jobCleanupTimeout = 10 * time.Second
+	jobCleanupPoll    = 100 * time.Millisecond
+)
+
+func WaitForCompletion(ctx context.Context, kubeClient client.WithWatch, task *controlapi.CassandraTask) error {
 	taskKey := types.NamespacedName{Name: task.Name, Namespace: task.Namespace}
 	return WaitForCompletionKey(ctx, kubeClient, taskKey)
 }
 
-// TODO Add --timeout parameter like kubectl wait has
+// WaitForCompletionKey watches the task until it has completed. The wait is limited by the context's deadline.
+// If the task failed, a *TaskFailedError is returned.
+func WaitForCompletionKey(ctx context.Context, kubeClient client.WithWatch, taskKey types.NamespacedName) error {
+	task := &controlapi.CassandraTask{}
+	if err := kubeClient.Get(ctx, taskKey, task); err != nil {
+		return err
+	}
+
+	// The failed pods are only visible in the pod annotations until the task completes. cass-operator removes them in
+	// the same reconcile which counts the last failure and completes the task, so every pod update is followed.
+	watchCtx, stopWatch := context.WithCancel(ctx)
+	defer stopWatch()
+	jobs := newJobWatch(task)
+	go jobs.run(watchCtx, kubeClient)
+
+	var completed *controlapi.CassandraTask
+	err := kubernetes.WaitForObject(ctx, kubeClient, taskKey, &controlapi.CassandraTask{}, &controlapi.CassandraTaskList{}, func(obj client.Object) (bool, error) {
+		task := obj.(*controlapi.CassandraTask)
+		if task.Status.CompletionTime != nil {
+			completed = task
+			return true, nil
+		}
+		return false, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	var failedPods []string
+	if completed.Status.Failed > 0 {
+		failedPods = jobs.failedPods(ctx)
+	}
+
+	return TaskError(completed, failedPods)
+}
+
+// jobWatch follows the job annotations of the task on the pods of its datacenter
+type jobWatch struct {
+	task *controlapi.CassandraTask
+
+	mu      sync.Mutex
+	synced  func() bool
+	running map[string]bool
+	failed  map[string]string
+}
+
+func newJobWatch(task *controlapi.CassandraTask) *jobWatch {
+	return &jobWatch{
+		task:    task,
+		synced:  func() bool { return false },
+		running: make(map[string]bool),
+		failed:  make(map[string]string),
+	}
+}
+
+func (w *jobWatch) run(ctx context.Context, kubeClient client.WithWatch) {
+	namespace := w.task.Spec.Datacenter.Namespace
+	if namespace == "" {
+		namespace = w.task.Namespace
+	}
 
-func WaitForCompletionKey(ctx context.Context, kubeClient client.Client, taskKey types.NamespacedName) error {
-	err := waitutil.PollImmediate(5*time.Second, 10*time.Minute, func() (done bool, err error) {
-		task := &controlapi.CassandraTask{}
-		if err := kubeClient.Get(ctx, taskKey, task); err != nil {
-			return false, err
+	update := func(obj interface{}) {
+		pod, ok := obj.(*corev1.Pod)
+		if !ok {
+			return
 		}
 
-		return task.Status.CompletionTime != nil, nil
+		w.mu.Lock()
+		defer w.mu.Unlock()
+
+		jobStatus, found := podJob(w.task, pod)
+		if !found {
+			delete(w.running, pod.Name)
+			return
+		}
+		w.running[pod.Name] = true
+		if jobStatus.Status == podJobError {
+			w.failed[pod.Name] = failedPodName(pod)
+		}
+	}
+
+	podLw := kubernetes.NewListWatch(ctx, kubeClient, &corev1.PodList{}, client.InNamespace(namespace), client.MatchingLabels{cassdcapi.DatacenterLabel: cassdcapi.CleanLabelValue(w.task.Spec.Datacenter.Name)})
+	_, podInformer := cache.NewInformer(podLw, &corev1.Pod{}, 0, cache.ResourceEventHandlerFuncs{
+		AddFunc:    update,
+		UpdateFunc: func(_, obj interface{}) { update(obj) },
+		DeleteFunc: func(obj interface{}) {
+			if pod, ok := obj.(*corev1.Pod); ok {
+				w.mu.Lock()
+				delete(w.running, pod.Name)
+				w.mu.Unlock()
+			}
+		},
 	})
 
-	return err
+	w.mu.Lock()
+	w.synced = podInformer.HasSynced
+	w.mu.Unlock()
+
+	podInformer.Run(ctx.Done())
+}
+
+// failedPods returns the pods which reported an error for the job, sorted. It waits until the watch has observed the
+// removal of the job annotations, as the errors are recorded before them, or at most jobCleanupTimeout.
+func (w *jobWatch) failedPods(ctx context.Context) []string {
+	ctx, cancel := context.WithTimeout(ctx, jobCleanupTimeout)
+	defer cancel()
+
+	ticker := time.NewTicker(jobCleanupPoll)
+	defer ticker.Stop()
+
+	for !w.cleanedUp() {
+		select {
+		case <-ctx.Done():
+			return w.sortedFailures()
+		case <-ticker.C:
+		}
+	}
+	return w.sortedFailures()
+}
+
+// cleanedUp returns true once the watch has synced and no pod has the job annotation anymore
+func (w *jobWatch) cleanedUp() bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.synced() && len(w.running) == 0
+}
+
+func (w *jobWatch) sortedFailures() []string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	failed := make([]string, 0, len(w.failed))
+	for _, pod := range w.failed {
+		failed = append(failed, pod)
+	}
+	sort.Strings(failed)
+	return failed
 }
@@ -0,0 +1,69 @@
+package tasks
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	cassdcapi "github.com/k8ssandra/cass-operator/apis/cassandra/v1beta1"
+	controlapi "github.com/k8ssandra/cass-operator/apis/control/v1alpha1"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestWaitForCompletionFailedOnCompletion(t *testing.T) {
+	require := require.New(t)
+
+	scheme := runtime.NewScheme()
+	require.NoError(clientgoscheme.AddToScheme(scheme))
+	require.NoError(controlapi.AddToScheme(scheme))
+
+	task := &controlapi.CassandraTask{ObjectMeta: metav1.ObjectMeta{Name: "cleanup", Namespace: "cass", UID: "1234"}}
+	task.Spec.Datacenter.Name = "dc1"
+
+	pod := func(name, rack string) *corev1.Pod {
+		return &corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "cass",
+			Labels:    map[string]string{cassdcapi.DatacenterLabel: "dc1", cassdcapi.RackLabel: rack},
+		}}
+	}
+	completedPod := pod("dc1-r1-sts-0", "r1")
+	failingPod := pod("dc1-r2-sts-0", "r2")
+
+	kubeClient := fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(task, completedPod, failingPod).Build()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() {
+		done <- WaitForCompletionKey(ctx, kubeClient, types.NamespacedName{Name: "cleanup", Namespace: "cass"})
+	}()
+
+	// The only failing pod reports its error while the task is running
+	time.Sleep(200 * time.Millisecond)
+	failingPod.Annotations = map[string]string{"control.k8ssandra.io/job-1234": `{"id":"a","status":"ERROR","handler":"management-api"}`}
+	require.NoError(kubeClient.Update(ctx, failingPod))
+
+	// The reconcile counting the failure removes the annotations and completes the task at the same time
+	time.Sleep(200 * time.Millisecond)
+	failingPod.Annotations = nil
+	require.NoError(kubeClient.Update(ctx, failingPod))
+	now := metav1.Now()
+	task.Status.Succeeded = 1
+	task.Status.Failed = 1
+	task.Status.CompletionTime = &now
+	require.NoError(kubeClient.Update(ctx, task))
+
+	err := <-done
+	var failedErr *TaskFailedError
+	require.True(errors.As(err, &failedErr))
+	require.Equal([]string{"dc1-r2-sts-0 (rack r2)"}, failedErr.Pods)
+}
@@ -0,0 +1,208 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"golang.org/x/term"
+)
+
+const (
+	maxProgressEvents = 5
+)
+
+var (
+	errInterrupted = fmt.Errorf("interrupted while waiting for the operation to finish")
+)
+
+// Progress receives the updates of a long running operation
+type Progress interface {
+	// Update sets the current status of a tracked item, such as a pod
+	Update(item, status string)
+	// Event records a one-off event, such as a condition transition
+	Event(message string)
+}
+
+// RunWithProgress executes the operation while rendering the progress it reports. On a terminal an interactive
+// view is shown, otherwise every update is written as a plain log line. The context given to the operation is
+// cancelled if the user interrupts the interactive view.
+func RunWithProgress(ctx context.Context, out io.Writer, title string, operation func(ctx context.Context, p Progress) error) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	if !isTerminal(out) {
+		fmt.Fprintln(out, title)
+		return operation(ctx, &plainProgress{out: out, start: time.Now()})
+	}
+
+	model := newProgressModel(title)
+	program := tea.NewProgram(model, tea.WithOutput(out))
+
+	go func() {
+		err := operation(ctx, &teaProgress{program: program})
+		program.Send(progressDoneMsg{err: err})
+	}()
+
+	if _, err := program.Run(); err != nil {
+		return err
+	}
+
+	if model.interrupted {
+		return errInterrupted
+	}
+
+	return model.err
+}
+
+func isTerminal(out io.Writer) bool {
+	f, ok := out.(*os.File)
+	return ok && term.IsTerminal(int(f.Fd()))
+}
+
+type plainProgress struct {
+	lock  sync.Mutex
+	out   io.Writer
+	start time.Time
+}
+
+func (p *plainProgress) Update(item, status string) {
+	p.println(fmt.Sprintf("%s: %s", item, status))
+}
+
+func (p *plainProgress) Event(message string) {
+	p.println(message)
+}
+
+func (p *plainProgress) println(line string) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	fmt.Fprintf(p.out, "[%s] %s\n", time.Since(p.start).Round(time.Second), line)
+}
+
+type teaProgress struct {
+	program *tea.Program
+}
+
+func (p *teaProgress) Update(item, status string) {
+	p.program.Send(progressItemMsg{item: item, status: status})
+}
+
+func (p *teaProgress) Event(message string) {
+	p.program.Send(progressEventMsg(message))
+}
+
+type progressItemMsg struct {
+	item   string
+	status string
+}
+
+type progressEventMsg string
+
+type progressDoneMsg struct {
+	err error
+}
+
+type progressTickMsg time.Time
+
+type progressModel struct {
+	title       string
+	start       time.Time
+	now         time.Time
+	items       []string
+	statuses    map[string]string
+	events      []string
+	err         error
+	done        bool
+	interrupted bool
+}
+
+func newProgressModel(title string) *progressModel {
+	now := time.Now()
+	return &progressModel{
+		title:    title,
+		start:    now,
+		now:      now,
+		statuses: make(map[string]string),
+	}
+}
+
+func tick() tea.Cmd {
+	return tea.Tick(time.Second, func(t time.Time) tea.Msg {
+		return progressTickMsg(t)
+	})
+}
+
+func (p *progressModel) Init() tea.Cmd {
+	return tick()
+}
+
+func (p *progressModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.Type {
+		case tea.KeyCtrlC, tea.KeyEsc:
+			p.interrupted = true
+			return p, tea.Quit
+		}
+	case progressTickMsg:
+		p.now = time.Time(msg)
+		return p, tick()
+	case progressItemMsg:
+		if _, found := p.statuses[msg.item]; !found {
+			p.items = append(p.items, msg.item)
+		}
+		p.statuses[msg.item] = msg.status
+	case progressEventMsg:
+		p.events = append(p.events, fmt.Sprintf("[%s] %s", time.Since(p.start).Round(time.Second), string(msg)))
+		if len(p.events) > maxProgressEvents {
+			p.events = p.events[len(p.events)-maxProgressEvents:]
+		}
+	case progressDoneMsg:
+		p.err = msg.err
+		p.done = true
+		p.now = time.Now()
+		return p, tea.Quit
+	}
+
+	return p, nil
+}
+
+func (p *progressModel) View() string {
+	itemStyle := lipgloss.NewStyle().Width(40)
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("%s (%s)\n\n", p.title, p.now.Sub(p.start).Round(time.Second)))
+
+	for _, item := range p.items {
+		sb.WriteString("  ")
+		sb.WriteString(itemStyle.Render(item))
+		sb.WriteString(p.statuses[item])
+		sb.WriteRune('\n')
+	}
+
+	if len(p.events) > 0 {
+		sb.WriteString("\nRecent events:\n")
+		for _, event := range p.events {
+			sb.WriteString("  ")
+			sb.WriteString(event)
+			sb.WriteRune('\n')
+		}
+	}
+
+	if p.done {
+		if p.err != nil {
+			sb.WriteString(fmt.Sprintf("\nFailed: %v\n", p.err))
+		} else {
+			sb.WriteString("\nDone\n")
+		}
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,9 @@
+package ui
+
+// ValueOrNone returns the value, or <none> for an empty value as kubectl shows missing values in tables
+func ValueOrNone(value string) string {
+	if value == "" {
+		return "<none>"
+	}
+	return value
+}
@@ -2,69 +2,81 @@ package users
 
 import (
 	"context"
+	"fmt"
+	"io"
+	"sort"
 
+	"github.com/k8ssandra/cass-operator/pkg/httphelper"
 	"github.com/k8ssandra/k8ssandra-client/pkg/cassdcutil"
 	"github.com/k8ssandra/k8ssandra-client/pkg/kubernetes"
-	"github.com/k8ssandra/k8ssandra-client/pkg/mgmtapi"
 	"github.com/k8ssandra/k8ssandra-client/pkg/secrets"
 
 	corev1 "k8s.io/api/core/v1"
 )
 
-func AddNewUsersFromSecret(ctx context.Context, c kubernetes.NamespacedClient, datacenter string, secretPath string, superusers bool) error {
-	// Create ManagementClient
-	mgmtClient, err := mgmtapi.NewManagementClient(ctx, c)
-	if err != nil {
-		return err
-	}
-
-	pod, err := targetPod(ctx, c, datacenter)
-	if err != nil {
-		return err
-	}
-
+// AddNewUsersFromSecret creates a role for every user of the secret at secretPath, see AddNewUsers
+func AddNewUsersFromSecret(ctx context.Context, c kubernetes.NamespacedClient, datacenter string, selector cassdcutil.PodSelector, secretPath string, superusers bool) error {
 	users, err := secrets.ReadTargetPath(secretPath)
 	if err != nil {
 		return err
 	}
 
-	for user, pass := range users {
-		if err := mgmtClient.CallCreateRoleEndpoint(pod, user, pass, superusers); err != nil {
-			return err
-		}
-	}
+	return AddNewUsers(ctx, c, datacenter, selector, users, superusers)
+}
 
-	return nil
+// AddNewUser creates the role through the management API of the preferred pod matching the selector, the other
+// pods are tried if the call fails
+func AddNewUser(ctx context.Context, c kubernetes.NamespacedClient, datacenter string, selector cassdcutil.PodSelector, username string, password string, superuser bool) error {
+	return AddNewUsers(ctx, c, datacenter, selector, map[string]string{username: password}, superuser)
 }
 
-func targetPod(ctx context.Context, c kubernetes.NamespacedClient, datacenter string) (*corev1.Pod, error) {
+// AddNewUsers creates the roles, mapped from username to password, with a single management API client. If a pod
+// fails, the roles not created yet are created through the next pod matching the selector.
+func AddNewUsers(ctx context.Context, c kubernetes.NamespacedClient, datacenter string, selector cassdcutil.PodSelector, users map[string]string, superuser bool) error {
 	cassManager := cassdcutil.NewManager(c)
 	dc, err := cassManager.CassandraDatacenter(ctx, datacenter, c.Namespace)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	podList, err := cassManager.CassandraDatacenterPods(ctx, dc)
-	if err != nil {
-		return nil, err
+	usernames := make([]string, 0, len(users))
+	for username := range users {
+		usernames = append(usernames, username)
 	}
+	sort.Strings(usernames)
 
-	return &podList.Items[0], nil
+	created := 0
+	return cassManager.CallPod(ctx, dc, selector, func(mgmtClient *httphelper.NodeMgmtClient, pod *corev1.Pod) error {
+		for ; created < len(usernames); created++ {
+			username := usernames[created]
+			if err := mgmtClient.CallCreateRoleEndpoint(pod, username, users[username], superuser); err != nil {
+				if len(usernames) == 1 {
+					return err
+				}
+				return fmt.Errorf("unable to create role %s: %w", username, err)
+			}
+		}
+		return nil
+	})
 }
 
-func AddNewUser(ctx context.Context, c kubernetes.NamespacedClient, datacenter string, username string, password string, superuser bool) error {
-	mgmtClient, err := mgmtapi.NewManagementClient(ctx, c)
+// DescribeNewUsers writes the roles which would be created and the pod used to create them, without creating them
+func DescribeNewUsers(ctx context.Context, c kubernetes.NamespacedClient, datacenter string, selector cassdcutil.PodSelector, usernames []string, superuser bool, out io.Writer) error {
+	cassManager := cassdcutil.NewManager(c)
+	dc, err := cassManager.CassandraDatacenter(ctx, datacenter, c.Namespace)
 	if err != nil {
 		return err
 	}
 
-	pod, err := targetPod(ctx, c, datacenter)
+	pods, err := cassManager.SelectPods(ctx, dc, selector)
 	if err != nil {
 		return err
 	}
 
-	if err := mgmtClient.CallCreateRoleEndpoint(pod, username, password, superuser); err != nil {
-		return err
+	sorted := append([]string(nil), usernames...)
+	sort.Strings(sorted)
+	for _, username := range sorted {
+		fmt.Fprintf(out, "Role %s (superuser: %t) would be created through pod %s\n", username, superuser, pods[0].Name)
 	}
 
 	return nil
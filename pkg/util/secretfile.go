@@ -0,0 +1,75 @@
+package util
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	utilrand "k8s.io/apimachinery/pkg/util/rand"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/kubectl/pkg/util/interrupt"
+)
+
+const (
+	// writeSecretFileScript writes the standard input to the file given as $0, readable by its owner only
+	writeSecretFileScript = `umask 077 && cat > "$0"`
+
+	// removeOnExitScript runs the command given as the arguments and removes the file given as $0 once the shell
+	// exits, also if the command is interrupted or the connection to the pod is lost
+	removeOnExitScript = `trap 'rm -f "$0"' EXIT; trap 'exit 130' HUP INT TERM; "$@"`
+)
+
+// SecretFileExec runs a command in the Cassandra container of a pod which reads a secret from a temporary file
+type SecretFileExec struct {
+	ConfigFlags *genericclioptions.ConfigFlags
+	genericclioptions.IOStreams
+	PodName string
+	// Name is included in the name of the temporary file
+	Name string
+	TTY  bool
+}
+
+// RemoveOnExitCommand wraps command in a shell which removes the file at path once the command exits
+func RemoveOnExitCommand(path string, command []string) []string {
+	return append([]string{"sh", "-c", removeOnExitScript, path}, command...)
+}
+
+// Run writes content to a temporary file readable only by its owner and runs the command returned by command with
+// the path of the file. The secret never appears on a command line. The file is removed by the shell running the
+// command in the pod and, as a fallback, by a separate exec once the command has returned or the process is
+// interrupted.
+func (s *SecretFileExec) Run(content string, command func(path string) []string) error {
+	path := fmt.Sprintf("/tmp/.%s-%s", s.Name, utilrand.String(8))
+
+	remove := func() {
+		if err := s.exec([]string{"rm", "-f", path}, genericclioptions.IOStreams{Out: io.Discard, ErrOut: io.Discard}, false, nil); err != nil {
+			fmt.Fprintf(s.ErrOut, "Warning: unable to remove %s from pod %s: %v\n", path, s.PodName, err)
+		}
+	}
+
+	handler := interrupt.New(func(os.Signal) { os.Exit(130) }, remove)
+	return handler.Run(func() error {
+		writeStreams := genericclioptions.IOStreams{In: strings.NewReader(content), Out: io.Discard, ErrOut: s.ErrOut}
+		if err := s.exec([]string{"sh", "-c", writeSecretFileScript, path}, writeStreams, false, nil); err != nil {
+			return fmt.Errorf("unable to write %s to pod %s: %w", path, s.PodName, err)
+		}
+
+		return s.exec(RemoveOnExitCommand(path, command(path)), s.IOStreams, s.TTY, handler)
+	})
+}
+
+func (s *SecretFileExec) exec(command []string, streams genericclioptions.IOStreams, tty bool, parent *interrupt.Handler) error {
+	execOptions, err := GetExecOptions(streams, s.ConfigFlags)
+	if err != nil {
+		return err
+	}
+
+	execOptions.PodName = s.PodName
+	execOptions.Command = command
+	execOptions.Stdin = streams.In != nil
+	execOptions.TTY = tty
+	execOptions.InterruptParent = parent
+
+	return execOptions.Run()
+}